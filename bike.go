@@ -2,18 +2,24 @@
 package bike
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 	"runtime"
-	"sync"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 // Bike is main struct of this package
 type Bike struct {
-	components   []*Component
-	customScopes map[Scope]string
+	components      []*Component
+	customScopes    map[Scope]string
+	modules         []*Module
+	profiles        []string
+	decorators      []any
+	startTimeout    time.Duration
+	shutdownTimeout time.Duration
 }
 
 // NewBike create a Bike instance
@@ -21,6 +27,7 @@ func NewBike() *Bike {
 	return &Bike{
 		components:   make([]*Component, 0),
 		customScopes: make(map[Scope]string, 0),
+		modules:      make([]*Module, 0),
 	}
 }
 
@@ -33,6 +40,48 @@ func (_self *Bike) Add(component Component) {
 	_self.components = append(_self.components, &component)
 }
 
+// AddModule add components of module and of modules imported by module
+func (_self *Bike) AddModule(module *Module) {
+	for _, added := range _self.modules {
+		if added == module {
+			return
+		}
+	}
+	_self.modules = append(_self.modules, module)
+	for _, imported := range module.Imports {
+		_self.AddModule(imported)
+	}
+	for _, component := range module.Components {
+		component.module = module
+		_self.Add(component)
+	}
+}
+
+// SetProfiles set active profiles, used by conditions of components
+func (_self *Bike) SetProfiles(profiles ...string) {
+	_self.profiles = profiles
+}
+
+// AddDecorator add a decorator func(inner T, dependencies...) T. The decorator wraps every component registered
+// by type T after PostConstruct, dependencies are resolved from container. Decorators are applied in the order they were added,
+// every decorator of a component must decorate the same type. Types of component the decorated instance isn't assignable to,
+// like the concrete type, resolve the undecorated instance
+func (_self *Bike) AddDecorator(decorator any) {
+	_self.decorators = append(_self.decorators, decorator)
+}
+
+// SetStartTimeout set the maximum time to wait for PostStart methods and Starter components on Start, zero means no timeout
+func (_self *Bike) SetStartTimeout(timeout time.Duration) {
+	_self.startTimeout = timeout
+}
+
+// SetShutdownTimeout set the maximum time Container.Run waits for Runner components to return and for Destroy methods
+// on shutdown, and StartContext waits for PostStart still running when start fails, zero means no timeout.
+// Runner components get the shutdown deadline with ShutdownContext
+func (_self *Bike) SetShutdownTimeout(timeout time.Duration) {
+	_self.shutdownTimeout = timeout
+}
+
 func (_self *Bike) AddCustomScope(newScope Scope, name string) *Error {
 	if newScope == Singleton || newScope == Prototype {
 		return &Error{
@@ -51,19 +100,29 @@ func (_self *Bike) AddCustomScope(newScope Scope, name string) *Error {
 }
 
 func (_self *Bike) validateComponent(component *Component) *Error {
+	// Check instance registered by AddInstance
+	if component.instance && component.Constructor == nil {
+		return &Error{
+			messageError: fmt.Sprintf("Error on Component %s. Instance must be a not nil pointer", componentDescription(component)),
+			errorCode:    InvalidInstance,
+			componentID:  component.ID}
+	}
+
 	// Check if component have not constructor method
 	if component.Constructor == nil {
 		return &Error{
-			messageError: fmt.Sprintf("Error on Component ID:[%s]. Constructor must not be nil", component.ID),
-			errorCode:    ComponentConstructorNull}
+			messageError: fmt.Sprintf("Error on Component %s. Constructor must not be nil", componentDescription(component)),
+			errorCode:    ComponentConstructorNull,
+			componentID:  component.ID}
 	}
 
 	// Check Scope
 	_, isCustomScope := _self.customScopes[component.Scope]
 	if component.Scope != Singleton && component.Scope != Prototype && !isCustomScope {
 		return &Error{
-			messageError: fmt.Sprintf("Error on Component ID:[%s]. Invalid Scope: %s", component.ID, component.Scope.String()),
-			errorCode:    InvalidScope}
+			messageError: fmt.Sprintf("Error on Component %s. Invalid Scope: %s", componentDescription(component), component.Scope.String()),
+			errorCode:    InvalidScope,
+			componentID:  component.ID}
 	}
 
 	// Check Constructor component
@@ -73,140 +132,257 @@ func (_self *Bike) validateComponent(component *Component) *Error {
 		errorType := reflect.TypeOf((*error)(nil)).Elem()
 		if !typeErrorReturn.Implements(errorType) {
 			return &Error{
-				messageError: fmt.Sprintf("Error on Component ID:[%s]. Last return value must be of error type", component.ID),
-				errorCode:    ConstructorLastReturnValueIsNotError}
+				messageError: fmt.Sprintf("Error on Component %s. Last return value must be of error type", componentDescription(component)),
+				errorCode:    ConstructorLastReturnValueIsNotError,
+				componentID:  component.ID}
 		}
 	} else if constructorType.NumOut() != 1 {
 		return &Error{
-			messageError: fmt.Sprintf("Error on Component ID:[%s]. Constructor must return one value", component.ID),
-			errorCode:    InvalidNumberOfReturnValuesOnConstructor}
+			messageError: fmt.Sprintf("Error on Component %s. Constructor must return one value", componentDescription(component)),
+			errorCode:    InvalidNumberOfReturnValuesOnConstructor,
+			componentID:  component.ID}
 	}
 	typeComponent := constructorType.Out(0)
 
 	// Check return Constructor value
 	if typeComponent.Kind() != reflect.Pointer && typeComponent.Kind() != reflect.Interface {
 		return &Error{
-			messageError: fmt.Sprintf("Error on Component ID:[%s]. Constructor must return a pointer o interface value", component.ID),
-			errorCode:    ConstructorReturnNoPointerValue}
+			messageError: fmt.Sprintf("Error on Component %s. Constructor must return a pointer o interface value", componentDescription(component)),
+			errorCode:    ConstructorReturnNoPointerValue,
+			componentID:  component.ID}
 	}
 
-	// Check PostConstruct
-	if len([]rune(component.PostConstruct)) > 0 {
+	// Check Interfaces
+	for _, inputInterface := range component.Interfaces {
+		if inputInterface == nil || !typeComponent.AssignableTo(typeOf(inputInterface)) {
+			return &Error{
+				messageError: fmt.Sprintf("Error on Component %s. Type %s returned by Constructor doesn't implement %v", componentDescription(component), typeComponent.String(), reflect.TypeOf(inputInterface)),
+				errorCode:    InterfaceNotImplemented,
+				componentID:  component.ID}
+		}
+	}
+
+	// Check fields with bike tag
+	if _, err := injectFieldsOf(typeComponent); err != nil {
+		return &Error{
+			messageError: fmt.Sprintf("Error on Component %s. %s", componentDescription(component), err.Error()),
+			errorCode:    err.ErrorCode(),
+			cause:        err,
+			componentID:  component.ID}
+	}
+
+	// Check Qualifiers
+	for index := range component.Qualifiers {
+		if index < 0 || index >= constructorType.NumIn() {
+			return &Error{
+				messageError: fmt.Sprintf("Error on Component %s. Qualifier of argument %d is invalid, Constructor has %d arguments", componentDescription(component), index, constructorType.NumIn()),
+				errorCode:    InvalidQualifier,
+				componentID:  component.ID}
+		}
+	}
+
+	// Check PostConstruct, ignored when component implements Initializer
+	if len([]rune(component.PostConstruct)) > 0 && !typeComponent.Implements(initializerType) {
 		componentType := constructorType.Out(0)
 		method, ok := componentType.MethodByName(component.PostConstruct)
 		if !ok {
 			return &Error{
-				messageError: fmt.Sprintf("Error on Component ID:[%s]. PostConstruct [%s] not found", component.ID, component.PostConstruct),
-				errorCode:    InvalidNumArgOnPostConstruct}
+				messageError: fmt.Sprintf("Error on Component %s. PostConstruct [%s] not found", componentDescription(component), component.PostConstruct),
+				errorCode:    InvalidNumArgOnPostConstruct,
+				componentID:  component.ID}
 		}
 		methodType := method.Type
 		if methodType.NumIn() == 2 {
 			inputType := methodType.In(1)
 			if inputType != reflect.TypeOf((*Container)(nil)) {
 				return &Error{
-					messageError: fmt.Sprintf("Error on Component ID:[%s]. Invalid argument type of PostConstruct:[%s], expected *Container, actual:%s", component.ID, component.PostConstruct, getTypeName(inputType)),
-					errorCode:    InvalidNumArgOnPostConstruct}
+					messageError: fmt.Sprintf("Error on Component %s. Invalid argument type of PostConstruct:[%s], expected *Container, actual:%s", componentDescription(component), component.PostConstruct, getTypeName(inputType)),
+					errorCode:    InvalidNumArgOnPostConstruct,
+					componentID:  component.ID}
 			}
 		} else if method.Type.NumIn() != 1 {
 			return &Error{
-				messageError: fmt.Sprintf("Error on Component ID:[%s]. Invalid argument number of PostConstruct:[%s], expected 0 or 1 arguments, actual:%d", component.ID, component.PostConstruct, method.Type.NumIn()),
-				errorCode:    InvalidNumArgOnPostConstruct}
+				messageError: fmt.Sprintf("Error on Component %s. Invalid argument number of PostConstruct:[%s], expected 0 or 1 arguments, actual:%d", componentDescription(component), component.PostConstruct, method.Type.NumIn()),
+				errorCode:    InvalidNumArgOnPostConstruct,
+				componentID:  component.ID}
 		}
 	}
 
-	// Check Destroy
-	if len([]rune(component.Destroy)) > 0 {
+	// Check Destroy, ignored when component implements Disposer
+	if len([]rune(component.Destroy)) > 0 && !typeComponent.Implements(disposerType) {
 		componentType := constructorType.Out(0)
 		method, ok := componentType.MethodByName(component.Destroy)
 		if !ok {
 			return &Error{
-				messageError: fmt.Sprintf("Error on Component ID:[%s]. Invalid Component.Destroy:%s", component.ID, component.Destroy),
-				errorCode:    InvalidNumArgOnPostConstruct}
+				messageError: fmt.Sprintf("Error on Component %s. Invalid Component.Destroy:%s", componentDescription(component), component.Destroy),
+				errorCode:    InvalidNumArgOnPostConstruct,
+				componentID:  component.ID}
 		}
 		if method.Type.NumIn() != 1 {
 			return &Error{
-				messageError: fmt.Sprintf("Error on Component ID:[%s]. Invalid number arguments of Destroy:[%s]", component.ID, component.Destroy),
-				errorCode:    InvalidNumArgOnPostConstruct}
+				messageError: fmt.Sprintf("Error on Component %s. Invalid number arguments of Destroy:[%s]", componentDescription(component), component.Destroy),
+				errorCode:    InvalidNumArgOnPostConstruct,
+				componentID:  component.ID}
 		}
 	}
 
-	// Check PostStart
-	if len([]rune(component.PostStart)) > 0 {
+	// Check Lazy
+	if component.Lazy && component.Scope != Singleton {
+		return &Error{
+			messageError: fmt.Sprintf("Error on Component %s. Lazy only supported when scope equal to Singleton", componentDescription(component)),
+			errorCode:    LazyWithScopeDifferentToSingleton,
+			componentID:  component.ID}
+	}
+	// Lazy singletons are created after PostStart methods are called
+	if component.Lazy && (len([]rune(component.PostStart)) > 0 || typeComponent.Implements(starterType)) {
+		return &Error{
+			messageError: fmt.Sprintf("Error on Component %s. Lazy isn't supported on components with PostStart or implementing bike.Starter", componentDescription(component)),
+			errorCode:    LazyWithPostStart,
+			componentID:  component.ID}
+	}
+
+	// Check PostStart, ignored when component implements Starter
+	if len([]rune(component.PostStart)) > 0 && !typeComponent.Implements(starterType) {
 
 		if component.Scope != Singleton {
 			return &Error{
-				messageError: fmt.Sprintf("Error on Component ID:[%s]. PostStart [%s] only supported when scope equal to Singleton", component.ID, component.PostStart),
-				errorCode:    PostStartWithScopeDifferentToSingleton}
+				messageError: fmt.Sprintf("Error on Component %s. PostStart [%s] only supported when scope equal to Singleton", componentDescription(component), component.PostStart),
+				errorCode:    PostStartWithScopeDifferentToSingleton,
+				componentID:  component.ID}
 		}
 
 		componentType := constructorType.Out(0)
 		method, ok := componentType.MethodByName(component.PostStart)
 		if !ok {
 			return &Error{
-				messageError: fmt.Sprintf("Error on Component ID:[%s]. PostStart [%s] not found", component.ID, component.PostStart),
-				errorCode:    InvalidNumArgOnPostConstruct}
+				messageError: fmt.Sprintf("Error on Component %s. PostStart [%s] not found", componentDescription(component), component.PostStart),
+				errorCode:    InvalidNumArgOnPostConstruct,
+				componentID:  component.ID}
 		}
-		if method.Type.NumIn() != 1 {
+		if method.Type.NumIn() == 2 {
+			inputType := method.Type.In(1)
+			if inputType != contextType {
+				return &Error{
+					messageError: fmt.Sprintf("Error on Component %s. Invalid argument type of PostStart [%s], expected context.Context, actual:%s", componentDescription(component), component.PostStart, getTypeName(inputType)),
+					errorCode:    InvalidNumArgOnPostConstruct,
+					componentID:  component.ID}
+			}
+		} else if method.Type.NumIn() != 1 {
 			return &Error{
-				messageError: fmt.Sprintf("Error on Component ID:[%s]. Invalid argument number of PostStart [%s]", component.ID, component.PostStart),
-				errorCode:    InvalidNumArgOnPostConstruct}
+				messageError: fmt.Sprintf("Error on Component %s. Invalid argument number of PostStart [%s]", componentDescription(component), component.PostStart),
+				errorCode:    InvalidNumArgOnPostConstruct,
+				componentID:  component.ID}
 		}
 	}
 
 	return nil
 }
 
-// Start start bike
-func (_self *Bike) Start() (*Container, *Error) {
+// newContainer return an empty container with custom scopes of bike
+func (_self *Bike) newContainer(parent *Container) *Container {
 	container := &Container{
-		componentsByType:           make(map[reflect.Type]*Component),
-		componentsByID:             make(map[string]*Component),
-		components:                 _self.components,
-		customScopeInstancesByType: make(map[Scope]map[string]map[reflect.Type]interface{}),
-		customScopeInstancesByID:   make(map[Scope]map[string]map[string]interface{}),
+		componentsByType:    make(map[reflect.Type][]*Component),
+		componentsByID:      make(map[string]*Component),
+		customScopes:        _self.customScopes,
+		profiles:            _self.profiles,
+		decorators:          _self.decorators,
+		shutdownTimeout:     _self.shutdownTimeout,
+		customScopeContexts: make(map[Scope]map[string]*scopeContext),
+		parent:              parent,
 	}
-
-	// 0. Create map with custom scopes
 	for key := range _self.customScopes {
-		container.customScopeInstancesByType[key] = make(map[string]map[reflect.Type]interface{})
-		container.customScopeInstancesByID[key] = make(map[string]map[string]interface{})
+		container.customScopeContexts[key] = make(map[string]*scopeContext)
 	}
+	return container
+}
 
-	for _, component := range container.components {
-		// 1. Validate
+// Start start bike, equivalent to StartContext with a background context
+func (_self *Bike) Start() (*Container, *Error) {
+	return _self.StartContext(context.Background())
+}
+
+// StartContext start bike, ctx is passed to PostStart methods and Starter components. When a PostStart fails, panics
+// or doesn't finish before start timeout, the container is stopped and an error listing every failure is returned.
+// PostStart still running are waited for within shutdown timeout before stopping the container, components whose
+// PostStart didn't return and their dependencies aren't destroyed
+func (_self *Bike) StartContext(ctx context.Context) (*Container, *Error) {
+	return _self.start(ctx, nil)
+}
+
+// start create a container with components of bike, parent is nil or the container to fall back on
+func (_self *Bike) start(ctx context.Context, parent *Container) (*Container, *Error) {
+	// 0. Create container
+	container := _self.newContainer(parent)
+
+	// 1. Validate
+	for _, component := range _self.components {
 		validateErr := _self.validateComponent(component)
 		if validateErr != nil {
 			return nil, validateErr
 		}
+	}
+	for _, decorator := range _self.decorators {
+		if err := validateDecorator(decorator); err != nil {
+			return nil, err
+		}
+	}
+	// Each container creates instances on its own copy of components
+	components := make([]*Component, len(_self.components))
+	for index, component := range _self.components {
+		copied := *component
+		components[index] = &copied
+	}
+	container.components = activeComponents(components, _self.profiles)
+	if errors := duplicateIDs(container.components); len(errors) > 0 {
+		return nil, errors[0]
+	}
+	for _, component := range container.components {
+		if err := container.validateDecoratorsOf(component); err != nil {
+			return nil, err
+		}
+	}
 
-		// 2. Registry
+	// 2. Registry
+	for _, component := range container.components {
 		container.registry(component)
+	}
+	for _, module := range _self.modules {
+		if err := module.validateExports(container); err != nil {
+			return nil, err
+		}
+	}
 
-		// 3. Create component
-		if component.Scope == Singleton {
-			instanceValue, err := container.createComponent(component, Singleton, "0")
-			if err != nil {
+	// 3. Build dependency graph
+	for _, component := range container.components {
+		dependencies, err := container.dependenciesOf(component)
+		if err != nil {
+			return nil, err
+		}
+		component.dependencies = dependencies
+	}
+	sortedComponents, sortErr := container.sortComponents()
+	if sortErr != nil {
+		return nil, sortErr
+	}
+	container.sortedComponents = sortedComponents
+
+	// 4. Create singletons no lazy, dependencies first
+	for _, component := range container.sortedComponents {
+		if component.Scope == Singleton && !component.Lazy {
+			if _, _, err := container.singletonInstance(component); err != nil {
 				return nil, err
 			}
-			component.instanceValue = instanceValue
 		}
 	}
 
-	// 4. PostStart
-	var wg sync.WaitGroup
-	for _, component := range container.components {
-		if component.Scope == Singleton && len([]rune(component.PostStart)) > 0 {
-			constructorType := reflect.TypeOf(component.Constructor)
-			componentType := constructorType.Out(0)
-			method, _ := componentType.MethodByName(component.PostStart)
-			wg.Add(1)
-			go func(internalComponent *Component) {
-				defer wg.Done()
-				method.Func.Call([]reflect.Value{*internalComponent.instanceValue})
-			}(component)
+	// 5. PostStart
+	if running, err := container.runPostStart(ctx, _self.startTimeout); err != nil {
+		errors := err.Errors()
+		if stopErr := container.stop(context.Background(), running); stopErr != nil {
+			errors = append(errors, stopErr)
 		}
+		return nil, joinErrors(PostStartReturnError, "Error on PostStart", errors)
 	}
-	wg.Wait()
 
 	return container, nil
 }
@@ -225,3 +401,11 @@ func getTypeName(_type reflect.Type) string {
 func getFuncName(component *Component) string {
 	return runtime.FuncForPC(reflect.ValueOf(component.Constructor).Pointer()).Name()
 }
+
+// constructorDescription return Constructor:[name] of component, or Instance:[type] for instances registered by AddInstance
+func constructorDescription(component *Component) string {
+	if component.instance {
+		return fmt.Sprintf("Instance:[%s]", component.componentType().String())
+	}
+	return fmt.Sprintf("Constructor:[%s]", getFuncName(component))
+}
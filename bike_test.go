@@ -446,6 +446,129 @@ func TestInstanceByType_GivenComponentWithADependency_WhenInstanceByType_ThenRet
 	}
 }
 
+func TestStart_GivenComponentAddedBeforeItsDependency_WhenStart_ThenCreateDependencyFirst(t *testing.T) {
+	// Given
+	bike := NewBike()
+	bike.Add(Component{
+		Constructor: NewB,
+		Scope:       Singleton,
+	})
+	bike.Add(Component{
+		Constructor: NewA,
+		Scope:       Singleton,
+	})
+	// When
+	container, startErr := bike.Start()
+	// Then
+	if startErr != nil {
+		t.Errorf("Start must no return an error. Error:%s", startErr.Error())
+		return
+	}
+	instance, err := container.InstanceByType((*B)(nil))
+	if err != nil {
+		t.Errorf("InstanceByType must no return an error")
+		return
+	}
+	if instance.(*B).a == nil {
+		t.Errorf("Start must inject dependency created after component")
+	}
+}
+
+type C struct {
+	b *B
+}
+
+func NewC(b *B) *C {
+	return &C{b: b}
+}
+
+func TestStart_GivenSingletonWithTransitiveDependencyOnPrototype_WhenStart_ThenReturnNilError(t *testing.T) {
+	// Given
+	bike := NewBike()
+	bike.Add(Component{
+		Constructor: NewC,
+		Scope:       Singleton,
+	})
+	bike.Add(Component{
+		Constructor: NewB,
+		Scope:       Prototype,
+	})
+	bike.Add(Component{
+		Constructor: NewA,
+		Scope:       Singleton,
+	})
+	// When
+	container, startErr := bike.Start()
+	// Then
+	if startErr != nil {
+		t.Errorf("Start must no return an error. Error:%s", startErr.Error())
+		return
+	}
+	instance, _ := container.InstanceByType((*C)(nil))
+	if instance.(*C).b.a == nil {
+		t.Errorf("Start must inject transitive dependencies")
+	}
+}
+
+type CycleA struct{}
+
+type CycleB struct{}
+
+type CycleC struct{}
+
+func NewCycleA(b *CycleB) *CycleA {
+	return &CycleA{}
+}
+
+func NewCycleB(c *CycleC) *CycleB {
+	return &CycleB{}
+}
+
+func NewCycleC(a *CycleA) *CycleC {
+	return &CycleC{}
+}
+
+func TestStart_GivenComponentsWithCircularDependency_WhenStart_ThenReturnErrorWithCyclePath(t *testing.T) {
+	// Given
+	bike := NewBike()
+	bike.Add(Component{ID: "A", Constructor: NewCycleA, Scope: Prototype})
+	bike.Add(Component{ID: "B", Constructor: NewCycleB, Scope: Singleton})
+	bike.Add(Component{ID: "C", Constructor: NewCycleC, Scope: Singleton})
+	// When
+	_, startErr := bike.Start()
+	// Then
+	if startErr == nil {
+		t.Errorf("Start must return an error")
+		return
+	}
+	if startErr.ErrorCode() != CircularDependency {
+		t.Errorf("Start must return CircularDependency error code, actual:%d", startErr.ErrorCode())
+	}
+	expected := "Circular dependency: A(github.com/kybsa/bike.NewCycleA) -> B(github.com/kybsa/bike.NewCycleB) -> " +
+		"C(github.com/kybsa/bike.NewCycleC) -> A(github.com/kybsa/bike.NewCycleA)"
+	if startErr.Error() != expected {
+		t.Errorf("Start must return cycle path, actual:%s", startErr.Error())
+	}
+}
+
+type SelfDependency struct{}
+
+func NewSelfDependency(self *SelfDependency) *SelfDependency {
+	return &SelfDependency{}
+}
+
+func TestStart_GivenComponentDependingOnItself_WhenStart_ThenReturnCircularDependencyError(t *testing.T) {
+	// Given
+	bike := NewBike()
+	bike.Add(Component{ID: "Self", Constructor: NewSelfDependency})
+	// When
+	_, startErr := bike.Start()
+	// Then
+	if startErr == nil || startErr.ErrorCode() != CircularDependency {
+		t.Errorf("Start must return CircularDependency error")
+	}
+}
+
 func TestStart_GivenComponentInvalidPostConstructName_WhenStart_ThenReturnError(t *testing.T) {
 	// Given
 	component := Component{
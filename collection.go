@@ -0,0 +1,36 @@
+package bike
+
+import "reflect"
+
+// isCollectionType return true when _type is a slice or a map with string key of pointers or interfaces.
+// Components can't be registered by these types, they are resolved with every component registered by type of elements
+func isCollectionType(_type reflect.Type) bool {
+	if _type.Kind() != reflect.Slice && (_type.Kind() != reflect.Map || _type.Key().Kind() != reflect.String) {
+		return false
+	}
+	return _type.Elem().Kind() == reflect.Pointer || _type.Elem().Kind() == reflect.Interface
+}
+
+// resolveCollection return a slice with instances of every component registered by type of elements in registration order,
+// or a map with instances by component ID. The lock of context must be held by caller
+func (_self *Container) resolveCollection(_type reflect.Type, module *Module, resolution *resolution) (interface{}, *Error) {
+	components := _self.componentsOf(_type.Elem(), module)
+	var collection reflect.Value
+	if _type.Kind() == reflect.Slice {
+		collection = reflect.MakeSlice(_type, 0, len(components))
+	} else {
+		collection = reflect.MakeMapWithSize(_type, len(components))
+	}
+	for _, component := range components {
+		instance, err := _self.instanceAs(component, _type.Elem(), resolution)
+		if err != nil {
+			return nil, err
+		}
+		if _type.Kind() == reflect.Slice {
+			collection = reflect.Append(collection, reflect.ValueOf(instance))
+		} else {
+			collection.SetMapIndex(reflect.ValueOf(component.ID), reflect.ValueOf(instance))
+		}
+	}
+	return collection.Interface(), nil
+}
@@ -0,0 +1,103 @@
+package bike
+
+import (
+	"errors"
+	"testing"
+)
+
+type Plugin interface {
+	Name() string
+}
+
+type FirstPlugin struct{}
+
+func (_self *FirstPlugin) Name() string {
+	return "first"
+}
+
+type SecondPlugin struct{}
+
+func (_self *SecondPlugin) Name() string {
+	return "second"
+}
+
+type PluginRegistry struct {
+	Plugins       []Plugin
+	PluginsByID   map[string]Plugin
+	FieldsPlugins []Plugin `bike:"inject"`
+}
+
+func NewPluginRegistry(plugins []Plugin, pluginsByID map[string]Plugin) *PluginRegistry {
+	return &PluginRegistry{Plugins: plugins, PluginsByID: pluginsByID}
+}
+
+func TestStart_GivenSeveralImplementations_WhenStart_ThenInjectEveryImplementationInRegistrationOrder(t *testing.T) {
+	// Given
+	bike := NewBike()
+	bike.Add(Component{Constructor: NewPluginRegistry})
+	bike.Add(Component{ID: "First", Constructor: func() *FirstPlugin { return &FirstPlugin{} }, Interfaces: []any{(*Plugin)(nil)}})
+	bike.Add(Component{ID: "Second", Constructor: func() *SecondPlugin { return &SecondPlugin{} }, Interfaces: []any{(*Plugin)(nil)}, Scope: Prototype})
+	// When
+	container, startErr := bike.Start()
+	// Then
+	if startErr != nil {
+		t.Errorf("Start must return nil error. Error:%s", startErr.Error())
+		return
+	}
+	registry := MustResolve[*PluginRegistry](container)
+	if len(registry.Plugins) != 2 || registry.Plugins[0].Name() != "first" || registry.Plugins[1].Name() != "second" {
+		t.Errorf("Start must inject every implementation in registration order")
+	}
+	if len(registry.PluginsByID) != 2 || registry.PluginsByID["First"].Name() != "first" || registry.PluginsByID["Second"].Name() != "second" {
+		t.Errorf("Start must inject every implementation by ID")
+	}
+	if len(registry.FieldsPlugins) != 2 {
+		t.Errorf("Start must inject every implementation on fields")
+	}
+}
+
+func TestStart_GivenNoImplementation_WhenStart_ThenInjectEmptyCollections(t *testing.T) {
+	// Given
+	bike := NewBike()
+	bike.Add(Component{Constructor: NewPluginRegistry})
+	// When
+	container, startErr := bike.Start()
+	// Then
+	if startErr != nil {
+		t.Errorf("Start must return nil error. Error:%s", startErr.Error())
+		return
+	}
+	registry := MustResolve[*PluginRegistry](container)
+	if registry.Plugins == nil || len(registry.Plugins) != 0 || registry.PluginsByID == nil || len(registry.PluginsByID) != 0 {
+		t.Errorf("Start must inject empty collections")
+	}
+}
+
+func TestStart_GivenImplementationReturnError_WhenStart_ThenReturnError(t *testing.T) {
+	// Given
+	bike := NewBike()
+	bike.Add(Component{Constructor: NewPluginRegistry})
+	bike.Add(Component{
+		Constructor: func() (*FirstPlugin, error) { return nil, errors.New("error") },
+		Interfaces:  []any{(*Plugin)(nil)},
+		Scope:       Prototype,
+	})
+	// When
+	_, startErr := bike.Start()
+	// Then
+	if startErr == nil || startErr.ErrorCode() != ConstructorReturnNotNilError {
+		t.Errorf("Start must return ConstructorReturnNotNilError error")
+	}
+}
+
+func TestStart_GivenSliceOfValues_WhenStart_ThenReturnDependencyByTypeNotFound(t *testing.T) {
+	// Given
+	bike := NewBike()
+	bike.Add(Component{Constructor: func(values []string) *A { return &A{} }})
+	// When
+	_, startErr := bike.Start()
+	// Then
+	if startErr == nil || startErr.ErrorCode() != DependencyByTypeNotFound {
+		t.Errorf("Start must return DependencyByTypeNotFound error")
+	}
+}
@@ -0,0 +1,89 @@
+package bike
+
+// Condition is evaluated by Bike.Start before registering a component, the component is registered when it returns true
+type Condition func(environment *Environment) bool
+
+// Environment is the input of conditions, contains active profiles and components already registered
+type Environment struct {
+	profiles   []string
+	components []*Component
+}
+
+// HasProfile return true when profile is active
+func (_self *Environment) HasProfile(profile string) bool {
+	for _, activeProfile := range _self.profiles {
+		if activeProfile == profile {
+			return true
+		}
+	}
+	return false
+}
+
+// HasType return true when a registered component provides inputType
+func (_self *Environment) HasType(inputType any) bool {
+	_type := typeOf(inputType)
+	for _, component := range _self.components {
+		for _, componentType := range component.types() {
+			if componentType == _type {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// OnProfile return a condition true when any of profiles is active
+func OnProfile(profiles ...string) Condition {
+	return func(environment *Environment) bool {
+		for _, profile := range profiles {
+			if environment.HasProfile(profile) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// OnMissingType return a condition true when no other component provides inputType
+func OnMissingType(inputType any) Condition {
+	return func(environment *Environment) bool {
+		return !environment.HasType(inputType)
+	}
+}
+
+// activeComponents return components without conditions and components with all conditions true.
+// Conditions are evaluated in registration order after registering components without conditions
+func activeComponents(components []*Component, profiles []string) []*Component {
+	environment := &Environment{profiles: profiles, components: make([]*Component, 0, len(components))}
+	for _, component := range components {
+		if len(component.Conditions) == 0 {
+			environment.components = append(environment.components, component)
+		}
+	}
+	for _, component := range components {
+		if len(component.Conditions) > 0 && component.matches(environment) {
+			environment.components = append(environment.components, component)
+		}
+	}
+	// Keep registration order
+	active := make([]*Component, 0, len(environment.components))
+	for _, component := range components {
+		for _, activeComponent := range environment.components {
+			if activeComponent == component {
+				active = append(active, component)
+				break
+			}
+		}
+	}
+	return active
+}
+
+// matches return true when all conditions of component are true
+func (_self *Component) matches(environment *Environment) bool {
+	for _, condition := range _self.Conditions {
+		if !condition(environment) {
+			return false
+		}
+	}
+	return true
+}
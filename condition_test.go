@@ -0,0 +1,139 @@
+package bike
+
+import "testing"
+
+type Database interface {
+	Dialect() string
+}
+
+type SqliteDatabase struct{}
+
+func NewSqliteDatabase() *SqliteDatabase {
+	return &SqliteDatabase{}
+}
+
+func (_self *SqliteDatabase) Dialect() string {
+	return "sqlite"
+}
+
+type PostgresDatabase struct{}
+
+func NewPostgresDatabase() *PostgresDatabase {
+	return &PostgresDatabase{}
+}
+
+func (_self *PostgresDatabase) Dialect() string {
+	return "postgres"
+}
+
+type InMemoryDatabase struct{}
+
+func NewInMemoryDatabase() *InMemoryDatabase {
+	return &InMemoryDatabase{}
+}
+
+func (_self *InMemoryDatabase) Dialect() string {
+	return "memory"
+}
+
+func newDatabaseBike(profiles ...string) *Bike {
+	bike := NewBike()
+	bike.SetProfiles(profiles...)
+	bike.Add(Component{
+		Constructor: NewSqliteDatabase,
+		Interfaces:  []any{(*Database)(nil)},
+		Conditions:  []Condition{OnProfile("dev", "test")},
+	})
+	bike.Add(Component{
+		Constructor: NewPostgresDatabase,
+		Interfaces:  []any{(*Database)(nil)},
+		Conditions:  []Condition{OnProfile("prod")},
+	})
+	bike.Add(Component{
+		Constructor: NewInMemoryDatabase,
+		Interfaces:  []any{(*Database)(nil)},
+		Conditions:  []Condition{OnMissingType((*Database)(nil))},
+	})
+	return bike
+}
+
+func TestOnProfile_GivenActiveProfile_WhenStart_ThenRegisterComponentOfProfile(t *testing.T) {
+	// Given
+	testCases := map[string]string{"dev": "sqlite", "test": "sqlite", "prod": "postgres"}
+	for profile, expected := range testCases {
+		bike := newDatabaseBike(profile)
+		// When
+		container, err := bike.Start()
+		// Then
+		if err != nil {
+			t.Errorf("Start must return nil error. Error:%s", err.Error())
+			continue
+		}
+		database, _ := Resolve[Database](container)
+		if database.Dialect() != expected {
+			t.Errorf("Start must register %s database on profile %s", expected, profile)
+		}
+	}
+}
+
+func TestOnMissingType_GivenNoActiveProfile_WhenStart_ThenRegisterComponent(t *testing.T) {
+	// Given
+	bike := newDatabaseBike()
+	// When
+	container, err := bike.Start()
+	// Then
+	if err != nil {
+		t.Errorf("Start must return nil error. Error:%s", err.Error())
+		return
+	}
+	database, _ := Resolve[Database](container)
+	if database.Dialect() != "memory" {
+		t.Error("Start must register component when no other component provides type")
+	}
+	if len(container.components) != 1 {
+		t.Error("Start must register only components with true conditions")
+	}
+}
+
+func TestOnMissingType_GivenComponentWithoutConditions_WhenStart_ThenNoRegisterComponent(t *testing.T) {
+	// Given
+	bike := NewBike()
+	bike.Add(Component{
+		Constructor: NewInMemoryDatabase,
+		Interfaces:  []any{(*Database)(nil)},
+		Conditions:  []Condition{OnMissingType((*Database)(nil))},
+	})
+	bike.Add(Component{Constructor: NewPostgresDatabase, Interfaces: []any{(*Database)(nil)}})
+	// When
+	container, err := bike.Start()
+	// Then
+	if err != nil {
+		t.Errorf("Start must return nil error. Error:%s", err.Error())
+		return
+	}
+	database, _ := Resolve[Database](container)
+	if database.Dialect() != "postgres" {
+		t.Error("Start must evaluate conditions after registering components without conditions")
+	}
+}
+
+func TestNewChild_GivenParentWithProfile_WhenNewChild_ThenEvaluateProfileOfParent(t *testing.T) {
+	// Given
+	bike := NewBike()
+	bike.SetProfiles("prod")
+	parent, _ := bike.Start()
+	// When
+	child, err := parent.NewChild(Component{
+		Constructor: NewPostgresDatabase,
+		Interfaces:  []any{(*Database)(nil)},
+		Conditions:  []Condition{OnProfile("prod")},
+	})
+	// Then
+	if err != nil {
+		t.Errorf("NewChild must return nil error. Error:%s", err.Error())
+		return
+	}
+	if _, errDatabase := Resolve[Database](child); errDatabase != nil {
+		t.Error("NewChild must register component of active profile of parent")
+	}
+}
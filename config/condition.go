@@ -0,0 +1,19 @@
+package config
+
+import "github.com/kybsa/bike"
+
+// OnKey return a condition true when key is present on configComponent
+func OnKey(configComponent ConfigComponent, key string) bike.Condition {
+	return func(environment *bike.Environment) bool {
+		_, ok := configComponent.Get(key)
+		return ok
+	}
+}
+
+// OnValue return a condition true when value of key on configComponent is equal to value
+func OnValue(configComponent ConfigComponent, key string, value string) bike.Condition {
+	return func(environment *bike.Environment) bool {
+		actual, ok := configComponent.Get(key)
+		return ok && actual == value
+	}
+}
@@ -0,0 +1,81 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/kybsa/bike"
+)
+
+type Storage interface {
+	Name() string
+}
+
+type MemoryStorage struct{}
+
+func (_self *MemoryStorage) Name() string {
+	return "memory"
+}
+
+type DiskStorage struct{}
+
+func (_self *DiskStorage) Name() string {
+	return "disk"
+}
+
+func newStorageBike(simpleConfig *SimpleConfig) *bike.Bike {
+	bk := bike.NewBike()
+	bk.Add(bike.Component{
+		Constructor: func() *MemoryStorage { return &MemoryStorage{} },
+		Interfaces:  []any{(*Storage)(nil)},
+		Conditions:  []bike.Condition{OnValue(simpleConfig, "storage", "memory")},
+	})
+	bk.Add(bike.Component{
+		Constructor: func() *DiskStorage { return &DiskStorage{} },
+		Interfaces:  []any{(*Storage)(nil)},
+		Conditions:  []bike.Condition{OnKey(simpleConfig, "storage.path")},
+	})
+	return bk
+}
+
+func TestOnValue_GivenKeyWithValue_WhenStart_ThenRegisterComponent(t *testing.T) {
+	// Given
+	bk := newStorageBike(&SimpleConfig{MapConfig: map[string]string{"storage": "memory"}})
+	// When
+	container, err := bk.Start()
+	// Then
+	if err != nil {
+		t.Errorf("Start must return nil error. Error:%s", err.Error())
+		return
+	}
+	storage, _ := bike.Resolve[Storage](container)
+	if storage == nil || storage.Name() != "memory" {
+		t.Error("Start must register component with value on config")
+	}
+}
+
+func TestOnKey_GivenKey_WhenStart_ThenRegisterComponent(t *testing.T) {
+	// Given
+	bk := newStorageBike(&SimpleConfig{MapConfig: map[string]string{"storage": "disk", "storage.path": "/tmp"}})
+	// When
+	container, err := bk.Start()
+	// Then
+	if err != nil {
+		t.Errorf("Start must return nil error. Error:%s", err.Error())
+		return
+	}
+	storage, _ := bike.Resolve[Storage](container)
+	if storage == nil || storage.Name() != "disk" {
+		t.Error("Start must register component with key on config")
+	}
+}
+
+func TestOnKey_GivenNoKey_WhenStart_ThenNoRegisterComponents(t *testing.T) {
+	// Given
+	bk := newStorageBike(&SimpleConfig{MapConfig: map[string]string{}})
+	// When
+	container, _ := bk.Start()
+	// Then
+	if _, err := bike.Resolve[Storage](container); err == nil {
+		t.Error("Start must not register components without key on config")
+	}
+}
@@ -0,0 +1,14 @@
+package config
+
+import "github.com/kybsa/bike"
+
+// NewModule return a module with simpleConfig, exports ConfigComponent and *SimpleConfig
+func NewModule(simpleConfig *SimpleConfig) *bike.Module {
+	return &bike.Module{
+		Name: "config",
+		Components: []bike.Component{
+			bike.InstanceComponent(simpleConfig, bike.WithID("SimpleConfig"), bike.WithInterfaces((*ConfigComponent)(nil))),
+		},
+		Exports: []any{(*ConfigComponent)(nil), (*SimpleConfig)(nil)},
+	}
+}
@@ -0,0 +1,25 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/kybsa/bike"
+)
+
+func TestNewModule_GivenSimpleConfig_WhenStart_ThenExportConfigComponent(t *testing.T) {
+	// Given
+	simpleConfig := &SimpleConfig{MapConfig: map[string]string{"key": "value"}}
+	bk := bike.NewBike()
+	bk.AddModule(NewModule(simpleConfig))
+	// When
+	container, err := bk.Start()
+	// Then
+	if err != nil {
+		t.Errorf("Start must return nil error. Error:%s", err.Error())
+		return
+	}
+	configComponent, errConfig := bike.Resolve[ConfigComponent](container)
+	if errConfig != nil || configComponent != simpleConfig {
+		t.Error("Module must export ConfigComponent")
+	}
+}
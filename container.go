@@ -2,162 +2,412 @@
 package bike
 
 import (
+	"context"
 	"fmt"
 	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 )
 
-// Container struct with component management
+// Container struct with component management.
+// Container is safe for concurrent use, instances of a custom scope context are created holding a lock by context
 type Container struct {
-	componentsByType           map[reflect.Type]*Component
-	componentsByID             map[string]*Component
-	components                 []*Component
-	customScopeInstancesByType map[Scope]map[string]map[reflect.Type]interface{}
-	customScopeInstancesByID   map[Scope]map[string]map[string]interface{}
+	componentsByType    map[reflect.Type][]*Component
+	componentsByID      map[string]*Component
+	components          []*Component
+	sortedComponents    []*Component
+	parent              *Container
+	customScopes        map[Scope]string
+	profiles            []string
+	decorators          []any
+	shutdownTimeout     time.Duration
+	contextsMutex       sync.RWMutex
+	customScopeContexts map[Scope]map[string]*scopeContext
+	// origin is the container sharing its contexts with a container bound to resolution,
+	// resolution holds the lock of its context while the bound container is passed to PostConstruct
+	origin     *Container
+	resolution *resolution
+}
+
+// resolution is the scope and context id where instances are resolved, context is the locked context
+// of a custom scope or nil for Singleton and Prototype. locked is true until the lock of context is released
+type resolution struct {
+	scope     Scope
+	idContext string
+	context   *scopeContext
+	locked    atomic.Bool
+}
+
+// scopeContext store instances of a context of custom scope, created includes prototypes created on context.
+// removed is true once RemoveContext destroyed its instances
+type scopeContext struct {
+	mutex     sync.Mutex
+	instances map[string]*scopeInstance
+	created   []*scopeInstance
+	removed   bool
+}
+
+// scopeInstance store an instance created on a context and the instance wrapped by decorators
+type scopeInstance struct {
+	component     *Component
+	instanceValue *reflect.Value
+	decorated     interface{}
 }
 
 // Registry a component to Container
 func (_self *Container) registry(component *Component) {
 	// Registry by id
 	_self.componentsByID[component.ID] = component
+	component.container = _self
 
-	constructorType := reflect.TypeOf(component.Constructor)
-	typeComponent := constructorType.Out(0)
-	_self.componentsByType[typeComponent] = component
-
-	// Registry by interfaces
-	for _, inter := range component.Interfaces {
-		interfaceType := reflect.TypeOf(inter).Elem()
-		_self.componentsByType[interfaceType] = component
+	// Registry by type and interfaces
+	for _, componentType := range component.types() {
+		_self.registryByType(componentType, component)
 	}
 
+	component.mutex = &sync.Mutex{}
+
 	// Init array of prototype instances
 	if component.Scope == Prototype {
 		component.prototypeInstancesValue = make([]*reflect.Value, 0)
 	}
 }
 
-func (_self *Container) instanceByTypeAny(inputType any, scope Scope, idContext string) (interface{}, *Error) {
-	_type := reflect.TypeOf(inputType)
-	if _type.Kind() == reflect.Pointer && _type.Elem().Kind() == reflect.Interface {
-		_type = _type.Elem()
+func (_self *Container) registryByType(_type reflect.Type, component *Component) {
+	for _, registered := range _self.componentsByType[_type] {
+		if registered == component {
+			return
+		}
 	}
-	return _self.instanceByType(_type, scope, idContext)
+	_self.componentsByType[_type] = append(_self.componentsByType[_type], component)
 }
 
-func (_self *Container) instanceByID(id string, scope Scope, idContext string) (interface{}, *Error) {
+// componentsOf return components registered by type visible on module, or singletons of parent
+// if none is registered on this container
+func (_self *Container) componentsOf(_type reflect.Type, module *Module) []*Component {
+	candidates := make([]*Component, 0)
+	for _, candidate := range _self.componentsByType[_type] {
+		if candidate.visibleTo(module, _type) {
+			candidates = append(candidates, candidate)
+		}
+	}
+	if len(candidates) > 0 || _self.parent == nil {
+		return candidates
+	}
+	return singletons(_self.parent.componentsOf(_type, module))
+}
+
+// componentOf return component registered by id visible on module, or singleton of parent
+// if it isn't registered on this container
+func (_self *Container) componentOf(id string, module *Module) (*Component, bool) {
 	component, ok := _self.componentsByID[id]
-	if ok {
+	if ok && component.visibleTo(module, nil) {
+		return component, true
+	}
+	if _self.parent == nil {
+		return nil, false
+	}
+	component, ok = _self.parent.componentOf(id, module)
+	if !ok || component.Scope != Singleton {
+		return nil, false
+	}
+	return component, true
+}
+
+func singletons(components []*Component) []*Component {
+	singletonComponents := make([]*Component, 0, len(components))
+	for _, component := range components {
 		if component.Scope == Singleton {
-			if component.instanceValue.Elem().CanAddr() {
-				return component.instanceValue.Elem().Addr().Interface(), nil
-			}
-			return component.instanceValue.Elem().Interface(), nil
+			singletonComponents = append(singletonComponents, component)
 		}
+	}
+	return singletonComponents
+}
 
-		if component.Scope != Prototype {
-			mapContext := _self.customScopeInstancesByID[scope][idContext]
-			if mapContext != nil {
-				instanceByContext := mapContext[id]
-				if instanceByContext != nil {
-					return instanceByContext, nil
-				}
+// componentByType return component registered by type. When several components are registered by type,
+// return the component with ID or Qualifier equal to qualifier or the primary component if qualifier is empty.
+// Fallback components are only candidates when no other component is registered by type
+func (_self *Container) componentByType(_type reflect.Type, qualifier string, module *Module) (*Component, *Error) {
+	candidates := _self.componentsOf(_type, module)
+	if len(candidates) == 0 {
+		return nil, &Error{messageError: "Component by type:" + getTypeName(_type) + " not found", errorCode: DependencyByTypeNotFound, dependencyType: _type}
+	}
+	if len([]rune(qualifier)) > 0 {
+		for _, candidate := range candidates {
+			if candidate.ID == qualifier || candidate.Qualifier == qualifier {
+				return candidate, nil
 			}
 		}
-
-		instance, err := _self.createComponent(component, scope, idContext)
-		if err != nil {
-			return nil, err
+		return nil, &Error{
+			messageError:   fmt.Sprintf("Component by type:%s and qualifier:[%s] not found", getTypeName(_type), qualifier),
+			errorCode:      DependencyByTypeNotFound,
+			dependencyType: _type}
+	}
+	candidates = withoutFallbacks(candidates)
+	if len(candidates) == 1 {
+		return candidates[0], nil
+	}
+	primaries := make([]*Component, 0)
+	for _, candidate := range candidates {
+		if candidate.Primary {
+			primaries = append(primaries, candidate)
 		}
-		var interfaceInstance any
-		if instance.Elem().CanAddr() {
-			interfaceInstance = instance.Elem().Addr().Interface()
-		} else {
-			interfaceInstance = instance.Elem().Interface()
+	}
+	if len(primaries) == 1 {
+		return primaries[0], nil
+	}
+	ids := make([]string, len(candidates))
+	for index, candidate := range candidates {
+		ids[index] = candidate.ID
+	}
+	return nil, &Error{
+		messageError:   fmt.Sprintf("Component by type:%s is ambiguous, candidates:[%s]. Mark one component as Primary or use a qualifier", getTypeName(_type), strings.Join(ids, ", ")),
+		errorCode:      AmbiguousDependency,
+		dependencyType: _type}
+}
+
+// withoutFallbacks return candidates without fallback components, or every candidate if all are fallback components
+func withoutFallbacks(candidates []*Component) []*Component {
+	components := make([]*Component, 0, len(candidates))
+	for _, candidate := range candidates {
+		if !candidate.Fallback {
+			components = append(components, candidate)
 		}
+	}
+	if len(components) == 0 {
+		return candidates
+	}
+	return components
+}
 
-		if component.Scope == Prototype {
-			component.prototypeInstancesValue = append(component.prototypeInstancesValue, instance)
-			return interfaceInstance, nil
-		} else {
-			_, ok := _self.customScopeInstancesByType[scope][idContext]
-			if !ok {
-				_self.customScopeInstancesByType[scope][idContext] = make(map[reflect.Type]interface{})
-			}
-			constructorType := reflect.TypeOf(component.Constructor)
-			typeComponent := constructorType.Out(0)
-			_self.customScopeInstancesByType[scope][idContext][typeComponent] = interfaceInstance
+// typeOf return type of inputType, or the interface type when inputType is a pointer to interface
+func typeOf(inputType any) reflect.Type {
+	_type := reflect.TypeOf(inputType)
+	if _type.Kind() == reflect.Pointer && _type.Elem().Kind() == reflect.Interface {
+		_type = _type.Elem()
+	}
+	return _type
+}
+
+func (_self *Container) instanceByTypeAny(inputType any, scope Scope, idContext string) (interface{}, *Error) {
+	return _self.instanceByType(typeOf(inputType), "", nil, scope, idContext)
+}
 
-			_, ok = _self.customScopeInstancesByID[scope][idContext]
-			if !ok {
-				_self.customScopeInstancesByID[scope][idContext] = make(map[string]interface{})
+// instanceByID return instance by id holding the lock of context
+func (_self *Container) instanceByID(id string, scope Scope, idContext string) (interface{}, *Error) {
+	resolution, unlock := _self.lockContext(scope, idContext)
+	defer unlock()
+	return _self.resolveByID(id, nil, resolution)
+}
+
+// instanceByType return instance by type visible on module holding the lock of context
+func (_self *Container) instanceByType(_type reflect.Type, qualifier string, module *Module, scope Scope, idContext string) (interface{}, *Error) {
+	resolution, unlock := _self.lockContext(scope, idContext)
+	defer unlock()
+	return _self.resolveByType(_type, qualifier, module, resolution)
+}
+
+// lockContext lock the context when scope is a custom scope, return the resolution on the locked context
+// and function to unlock it. A context removed while waiting for the lock is replaced by a new context.
+// The resolution of the container holding the lock of the same context is reused without locking
+func (_self *Container) lockContext(scope Scope, idContext string) (*resolution, func()) {
+	if held := _self.resolution; held != nil && held.locked.Load() && held.scope == scope && held.idContext == idContext {
+		return held, func() {}
+	}
+	for {
+		context := _self.context(scope, idContext)
+		resolution := &resolution{scope: scope, idContext: idContext, context: context}
+		if context == nil {
+			return resolution, func() {}
+		}
+		context.mutex.Lock()
+		if !context.removed {
+			resolution.locked.Store(true)
+			return resolution, func() {
+				resolution.locked.Store(false)
+				context.mutex.Unlock()
 			}
-			_self.customScopeInstancesByID[scope][idContext][component.ID] = interfaceInstance
 		}
-		return interfaceInstance, nil
+		context.mutex.Unlock()
+	}
+}
+
+// boundTo return a container resolving instances of the context locked by resolution without waiting for its lock,
+// or this container when resolution has no context. It shares components and contexts with this container
+func (_self *Container) boundTo(resolution *resolution) *Container {
+	if resolution.context == nil {
+		return _self
+	}
+	origin := _self
+	if _self.origin != nil {
+		origin = _self.origin
+	}
+	return &Container{
+		componentsByType:    _self.componentsByType,
+		componentsByID:      _self.componentsByID,
+		components:          _self.components,
+		sortedComponents:    _self.sortedComponents,
+		parent:              _self.parent,
+		customScopes:        _self.customScopes,
+		profiles:            _self.profiles,
+		decorators:          _self.decorators,
+		shutdownTimeout:     _self.shutdownTimeout,
+		customScopeContexts: _self.customScopeContexts,
+		origin:              origin,
+		resolution:          resolution,
+	}
+}
+
+// contextsLock return the mutex guarding contexts of custom scopes, the mutex of origin on a bound container
+func (_self *Container) contextsLock() *sync.RWMutex {
+	if _self.origin != nil {
+		return &_self.origin.contextsMutex
+	}
+	return &_self.contextsMutex
+}
+
+// context return context of custom scope, create it if doesn't exist. Return nil if scope isn't a custom scope
+func (_self *Container) context(scope Scope, idContext string) *scopeContext {
+	_self.contextsLock().RLock()
+	contexts, isCustomScope := _self.customScopeContexts[scope]
+	context := contexts[idContext]
+	_self.contextsLock().RUnlock()
+	if !isCustomScope || context != nil {
+		return context
+	}
+
+	_self.contextsLock().Lock()
+	defer _self.contextsLock().Unlock()
+	context, ok := contexts[idContext]
+	if !ok {
+		context = &scopeContext{instances: make(map[string]*scopeInstance)}
+		contexts[idContext] = context
+	}
+	return context
+}
+
+func (_self *Container) resolveByID(id string, module *Module, resolution *resolution) (interface{}, *Error) {
+	component, ok := _self.componentOf(id, module)
+	if ok {
+		decorated, _, err := _self.instanceOf(component, resolution)
+		return decorated, err
 	}
 	message := "Component by id:" + id + " not found"
 	return nil, &Error{messageError: message, errorCode: DependencyByIDNotFound}
 }
 
-func (_self *Container) instanceByType(_type reflect.Type, scope Scope, idContext string) (interface{}, *Error) {
-	component, ok := _self.componentsByType[_type]
-	if ok {
-		if component.Scope == Singleton {
-			return component.instanceValue.Elem().Addr().Interface(), nil
+func (_self *Container) resolveByType(_type reflect.Type, qualifier string, module *Module, resolution *resolution) (interface{}, *Error) {
+	if isCollectionType(_type) {
+		return _self.resolveCollection(_type, module, resolution)
+	}
+	if isOptionalType(_type) {
+		return _self.resolveOptional(_type, qualifier, module, resolution)
+	}
+	if isProviderType(_type) {
+		return _self.resolveProvider(_type, qualifier, module, resolution), nil
+	}
+	component, err := _self.componentByType(_type, qualifier, module)
+	if err != nil {
+		return nil, err
+	}
+	return _self.instanceAs(component, _type, resolution)
+}
+
+// instanceAs return instance of component as _type. A decorated instance may not be assignable to every type
+// of component, like a decorator of an interface for the concrete type, then the undecorated instance is returned
+func (_self *Container) instanceAs(component *Component, _type reflect.Type, resolution *resolution) (interface{}, *Error) {
+	decorated, instance, err := _self.instanceOf(component, resolution)
+	if err != nil {
+		return nil, err
+	}
+	if decorated != nil && !reflect.TypeOf(decorated).AssignableTo(_type) {
+		return instance, nil
+	}
+	return decorated, nil
+}
+
+// singletonInstance return decorated and undecorated instance of singleton component, create it if doesn't exist
+func (_self *Container) singletonInstance(component *Component) (*reflect.Value, *reflect.Value, *Error) {
+	// Components of parent are created by parent
+	if component.container != _self {
+		return component.container.singletonInstance(component)
+	}
+	component.mutex.Lock()
+	defer component.mutex.Unlock()
+	if component.instanceValue == nil {
+		resolution := &resolution{scope: Singleton, idContext: "0"}
+		instanceValue, err := _self.createComponent(component, resolution)
+		if err != nil {
+			return nil, nil, err
+		}
+		decoratedValue, err := _self.decorate(component, instanceValue, resolution)
+		if err != nil {
+			return nil, nil, err
 		}
+		component.instanceValue = instanceValue
+		component.decoratedValue = decoratedValue
+	}
+	return component.decoratedValue, component.instanceValue, nil
+}
 
-		if component.Scope != Prototype {
-			mapContext := _self.customScopeInstancesByType[scope][idContext]
-			if mapContext != nil {
-				instanceByContext := mapContext[_type]
-				if instanceByContext != nil {
-					return instanceByContext, nil
-				}
-			}
+// instanceOf return decorated and undecorated instance of component, the lock of context of resolution must be held by caller
+func (_self *Container) instanceOf(component *Component, resolution *resolution) (interface{}, interface{}, *Error) {
+	if component.Scope == Singleton {
+		decoratedValue, instanceValue, err := _self.singletonInstance(component)
+		if err != nil {
+			return nil, nil, err
 		}
+		return decoratedValue.Interface(), instanceValue.Interface(), nil
+	}
 
-		instance, err := _self.createComponent(component, scope, idContext)
+	if component.Scope == Prototype {
+		instance, err := _self.createComponent(component, resolution)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
-		var interfaceInstance any
-		if instance.Elem().CanAddr() {
-			interfaceInstance = instance.Elem().Addr().Interface()
+		// Prototypes created on a context are destroyed by RemoveContext, otherwise by Stop
+		if context := resolution.context; context != nil {
+			context.created = append(context.created, &scopeInstance{component: component, instanceValue: instance})
 		} else {
-			interfaceInstance = instance.Elem().Interface()
-		}
-		if component.Scope == Prototype {
+			component.mutex.Lock()
 			component.prototypeInstancesValue = append(component.prototypeInstancesValue, instance)
-		} else {
-			_, ok := _self.customScopeInstancesByType[scope][idContext]
-			// TODO El problema es que "component" almacenado en customScope no tiene definido instanceValue
-			//  Entonces siempre se regresa nulo, sin embargo
-			// PUEDE QUe lo mejor sea almacenar en no el Component sino el value o
-			// de lo contrario crear una copia del componente!!!!
-			if !ok {
-				_self.customScopeInstancesByType[scope][idContext] = make(map[reflect.Type]interface{})
-			}
-			_self.customScopeInstancesByType[scope][idContext][_type] = interfaceInstance
-
-			_, ok = _self.customScopeInstancesByID[scope][idContext]
-			if !ok {
-				_self.customScopeInstancesByID[scope][idContext] = make(map[string]interface{})
-			}
-			_self.customScopeInstancesByID[scope][idContext][component.ID] = interfaceInstance
+			component.mutex.Unlock()
 		}
-		return interfaceInstance, nil
+		decorated, err := _self.decorate(component, instance, resolution)
+		if err != nil {
+			return nil, nil, err
+		}
+		return decorated.Interface(), instance.Interface(), nil
 	}
-	var message string
-	if _type.Kind() == reflect.Interface {
-		message = "Component by type:" + _type.Name() + " not found"
 
-	} else {
-		message = "Component by type:" + getTypeName(_type) + " not found"
+	context := resolution.context
+	if context == nil {
+		return nil, nil, &Error{
+			messageError: fmt.Sprintf("Error on Component %s. Component with scope %s can't be created out of a context, current scope:%s", componentDescription(component), component.Scope.String(), resolution.scope.String()),
+			errorCode:    InvalidScope,
+			componentID:  component.ID}
+	}
+	if created, ok := context.instances[component.ID]; ok {
+		return created.decorated, created.instanceValue.Interface(), nil
+	}
+	instance, err := _self.createComponent(component, resolution)
+	if err != nil {
+		return nil, nil, err
 	}
-	return nil, &Error{messageError: message, errorCode: DependencyByTypeNotFound}
+	created := &scopeInstance{component: component, instanceValue: instance}
+	context.created = append(context.created, created)
+	decorated, err := _self.decorate(component, instance, resolution)
+	if err != nil {
+		return nil, nil, err
+	}
+	created.decorated = decorated.Interface()
+	context.instances[component.ID] = created
+	return created.decorated, instance.Interface(), nil
 }
 
-func (_self *Container) createComponent(component *Component, scope Scope, idContext string) (*reflect.Value, *Error) {
+func (_self *Container) createComponent(component *Component, resolution *resolution) (*reflect.Value, *Error) {
 	// Create component by constructor method
 	constructorValue := reflect.ValueOf(component.Constructor)
 	constructorType := reflect.TypeOf(component.Constructor)
@@ -166,90 +416,115 @@ func (_self *Container) createComponent(component *Component, scope Scope, idCon
 	args := make([]reflect.Value, constructorType.NumIn())
 	for i := 0; i < constructorType.NumIn(); i++ {
 		inputType := constructorType.In(i)
-		inputArg, err := _self.instanceByType(inputType, scope, idContext)
+		inputArg, err := _self.resolveByType(inputType, component.Qualifiers[i], component.module, resolution)
 		if err == nil {
 			args[i] = reflect.ValueOf(inputArg)
 		} else {
 			return nil, &Error{
-				messageError: fmt.Sprintf("Error on Component ID:[%s]. Error to get dependency: [%s] required by Constructor:[%s]", component.ID, getTypeName(inputType), getFuncName(component)),
-				errorCode:    err.ErrorCode()}
+				messageError:   fmt.Sprintf("Error on Component %s. Error to get dependency: [%s] required by Constructor:[%s]. %s", componentDescription(component), getTypeName(inputType), getFuncName(component), err.Error()),
+				errorCode:      err.ErrorCode(),
+				cause:          err,
+				dependencyType: inputType,
+				componentID:    component.ID}
 		}
 	}
 	// Create component with dependencies
-	instanceResult := constructorValue.Call(args)
+	var instanceResult []reflect.Value
+	if err := callRecovering(component, ConstructorPanicked, "Constructor", func() {
+		instanceResult = constructorValue.Call(args)
+	}); err != nil {
+		return nil, err
+	}
 
 	if len(instanceResult) == 2 {
 		errorElem := instanceResult[1].Elem()
 		if !instanceResult[1].IsNil() && errorElem.Interface() != nil {
 			constructorError := errorElem.Interface().(error)
 			return nil, &Error{
-				messageError: fmt.Sprintf("Error on Component ID:[%s]. Constructor return an error:[%s]", component.ID, constructorError.Error()),
-				errorCode:    ConstructorReturnNotNilError}
+				messageError: fmt.Sprintf("Error on Component %s. Constructor return an error:[%s]", componentDescription(component), constructorError.Error()),
+				errorCode:    ConstructorReturnNotNilError,
+				cause:        constructorError,
+				componentID:  component.ID}
 		}
 	}
 
 	instanceValue := &instanceResult[0]
 
-	// Call PostConstruct
-	componentType := constructorType.Out(0)
-	if len([]rune(component.PostConstruct)) > 0 {
-		method, _ := componentType.MethodByName(component.PostConstruct)
-		typeError := reflect.TypeOf((*error)(nil)).Elem()
-
-		var in []reflect.Value
-		if method.Type.NumIn() == 2 {
-			in = []reflect.Value{*instanceValue, reflect.ValueOf(_self)}
-		} else {
-			in = []reflect.Value{*instanceValue}
-		}
+	// Instances registered by AddInstance are already initialized
+	if component.instance {
+		return instanceValue, nil
+	}
 
-		returnValues := method.Func.Call(in)
-		for _, value := range returnValues {
-			if value.Type().Implements(typeError) {
-				err := value.Elem().Interface().(error)
-				return nil, &Error{
-					messageError: fmt.Sprintf("Error on Component ID:[%s]. PostConstruct return an error:[%s]", component.ID, err.Error()),
-					errorCode:    PostConstructReturnError}
-			}
-		}
+	// Inject fields with bike tag
+	if err := _self.injectFields(component, instanceValue, resolution); err != nil {
+		return nil, err
+	}
+
+	// Call PostConstruct
+	if err := _self.callPostConstruct(component, instanceValue, resolution); err != nil {
+		return nil, err
 	}
 
 	return instanceValue, nil
 }
 
-// Stop stop container
+// Stop stop container, equivalent to StopContext with a background context
 func (_self *Container) Stop() *Error {
-	typeError := reflect.TypeOf((*error)(nil)).Elem()
-	for _, component := range _self.components {
-		if len([]rune(component.Destroy)) > 0 {
-			componentType := reflect.TypeOf(component.Constructor).Out(0)
-			method, _ := componentType.MethodByName(component.Destroy)
-			if component.Scope == Singleton {
-				returnValues := method.Func.Call([]reflect.Value{*component.instanceValue})
-				for _, value := range returnValues {
-					if value.Type().Implements(typeError) {
-						err := value.Elem().Interface().(error)
-						return &Error{
-							messageError: fmt.Sprintf("Error on Component ID:[%s]. Destroy return an error:[%s]", component.ID, err.Error()),
-							errorCode:    PostConstructReturnError}
-					}
-				}
-			} else if component.Scope == Prototype {
-				for _, prototypeInstance := range component.prototypeInstancesValue {
-					returnValues := method.Func.Call([]reflect.Value{*prototypeInstance})
-					for _, value := range returnValues {
-						if value.Type().Implements(typeError) {
-							err := value.Elem().Interface().(error)
-							return &Error{
-								messageError: fmt.Sprintf("Error on Component ID:[%s]. Destroy return an error:[%s]", component.ID, err.Error()),
-								errorCode:    PostConstructReturnError}
-						}
-					}
+	return _self.StopContext(context.Background())
+}
+
+// StopContext stop container, components are destroyed in reverse dependency order. ctx is passed to Disposer components.
+// Instances created on contexts of custom scopes are destroyed by RemoveContext. Return an error listing every Destroy method that failed
+func (_self *Container) StopContext(ctx context.Context) *Error {
+	return _self.stop(ctx, nil)
+}
+
+// stop destroy components in reverse dependency order except running components and their dependencies,
+// still used by running components
+func (_self *Container) stop(ctx context.Context, running []*Component) *Error {
+	used := make(map[*Component]bool)
+	var use func(component *Component)
+	use = func(component *Component) {
+		if used[component] {
+			return
+		}
+		used[component] = true
+		for _, dependency := range component.dependencies {
+			use(dependency)
+		}
+	}
+	for _, component := range running {
+		use(component)
+	}
+	errors := make([]*Error, 0)
+	for index := len(_self.sortedComponents) - 1; index >= 0; index-- {
+		component := _self.sortedComponents[index]
+		if used[component] {
+			continue
+		}
+		if component.Scope == Singleton {
+			component.mutex.Lock()
+			instanceValue := component.instanceValue
+			component.mutex.Unlock()
+			// Lazy singletons are only destroyed when they were created
+			if instanceValue == nil {
+				continue
+			}
+			if err := callDestroy(ctx, component, instanceValue); err != nil {
+				errors = append(errors, err)
+			}
+		} else if component.Scope == Prototype {
+			component.mutex.Lock()
+			prototypeInstancesValue := component.prototypeInstancesValue
+			component.mutex.Unlock()
+			for prototypeIndex := len(prototypeInstancesValue) - 1; prototypeIndex >= 0; prototypeIndex-- {
+				if err := callDestroy(ctx, component, prototypeInstancesValue[prototypeIndex]); err != nil {
+					errors = append(errors, err)
 				}
 			}
 		}
 	}
-	return nil
+	return joinErrors(DestroyReturnError, "Error on Stop container", errors)
 }
 
 // InstanceByType return a instance by type
@@ -257,6 +532,11 @@ func (_self *Container) InstanceByType(inputType any) (interface{}, *Error) {
 	return _self.instanceByTypeAny(inputType, Singleton, "0")
 }
 
+// InstanceByTypeAndQualifier return a instance by type and ID or Qualifier of component
+func (_self *Container) InstanceByTypeAndQualifier(inputType any, qualifier string) (interface{}, *Error) {
+	return _self.instanceByType(typeOf(inputType), qualifier, nil, Singleton, "0")
+}
+
 // InstanceByID return a instance by ID
 func (_self *Container) InstanceByID(id string) (interface{}, *Error) {
 	return _self.instanceByID(id, Singleton, "0")
@@ -272,18 +552,54 @@ func (_self *Container) InstanceByIDAndIDContext(id string, scope Scope, idConte
 	return _self.instanceByID(id, scope, idContext)
 }
 
+// RemoveContext remove a context of custom scope, instances created on context, prototypes included,
+// are destroyed in reverse creation order.
+// Return an error listing every Destroy method that failed
 func (_self *Container) RemoveContext(scope Scope, idContext string) *Error {
+	_self.contextsLock().Lock()
 	// Remove context or error if doesn't exist
-	if _, ok := _self.customScopeInstancesByID[scope]; !ok {
+	if _, ok := _self.customScopeContexts[scope]; !ok {
+		_self.contextsLock().Unlock()
 		return &Error{
 			messageError: fmt.Sprintf("Invalid Scope:[%d]", scope),
 			errorCode:    InvalidScope}
 	}
-	if _, ok := _self.customScopeInstancesByID[scope][idContext]; !ok {
+	removed, ok := _self.customScopeContexts[scope][idContext]
+	if !ok {
+		_self.contextsLock().Unlock()
 		return &Error{
 			messageError: fmt.Sprintf("Context id:[%s] not found", idContext),
 			errorCode:    InvalidScope}
 	}
-	delete(_self.customScopeInstancesByID[scope], idContext)
-	return nil
+	delete(_self.customScopeContexts[scope], idContext)
+	_self.contextsLock().Unlock()
+
+	// Wait for instances being created on context
+	removed.mutex.Lock()
+	defer removed.mutex.Unlock()
+	errors := make([]*Error, 0)
+	for index := len(removed.created) - 1; index >= 0; index-- {
+		created := removed.created[index]
+		if err := callDestroy(context.Background(), created.component, created.instanceValue); err != nil {
+			errors = append(errors, err)
+		}
+	}
+	removed.instances = nil
+	removed.created = nil
+	removed.removed = true
+	return joinErrors(DestroyReturnError, fmt.Sprintf("Error on remove context id:[%s]", idContext), errors)
+}
+
+// NewChild create a child container with components. The child resolves its own components first
+// and then falls back to singletons of this container. Profiles and decorators of this container are active on child. Stop of child only destroys components of child
+func (_self *Container) NewChild(components ...Component) (*Container, *Error) {
+	bike := NewBike()
+	bike.customScopes = _self.customScopes
+	bike.profiles = _self.profiles
+	bike.decorators = _self.decorators
+	bike.shutdownTimeout = _self.shutdownTimeout
+	for _, component := range components {
+		bike.Add(component)
+	}
+	return bike.start(context.Background(), _self)
 }
@@ -1,6 +1,12 @@
 package bike
 
-import "testing"
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
 
 func Test_GivenCustomScope_WhenGetInstanceByType_ThenReturnNotNil(t *testing.T) {
 	// Given
@@ -194,3 +200,851 @@ func Test_GivenInvalidIdContext_WhenRemoveContext_ThenReturnError(t *testing.T)
 		t.Errorf("RemoveContext must return an error")
 	}
 }
+
+type DestroyRecorder struct {
+	destroyed []string
+}
+
+type DatabaseComponent struct {
+	recorder *DestroyRecorder
+}
+
+func (_self *DatabaseComponent) Close() {
+	_self.recorder.destroyed = append(_self.recorder.destroyed, "database")
+}
+
+func (_self *DatabaseComponent) CloseError() error {
+	return errors.New("database error")
+}
+
+type ServiceComponent struct {
+	recorder *DestroyRecorder
+}
+
+func (_self *ServiceComponent) Close() {
+	_self.recorder.destroyed = append(_self.recorder.destroyed, "service")
+}
+
+func (_self *ServiceComponent) CloseError() error {
+	return errors.New("service error")
+}
+
+func Test_GivenServiceDependingOnDatabase_WhenStop_ThenDestroyServiceFirst(t *testing.T) {
+	// Given
+	recorder := &DestroyRecorder{}
+	bike := NewBike()
+	bike.Add(Component{
+		Constructor: func(database *DatabaseComponent) *ServiceComponent {
+			return &ServiceComponent{recorder: recorder}
+		},
+		Destroy: "Close",
+	})
+	bike.Add(Component{
+		Constructor: func() *DatabaseComponent { return &DatabaseComponent{recorder: recorder} },
+		Destroy:     "Close",
+	})
+	container, _ := bike.Start()
+	// When
+	stopErr := container.Stop()
+	// Then
+	if stopErr != nil {
+		t.Errorf("Stop must return nil error")
+	}
+	if len(recorder.destroyed) != 2 || recorder.destroyed[0] != "service" || recorder.destroyed[1] != "database" {
+		t.Errorf("Stop must destroy components in reverse dependency order, actual:%v", recorder.destroyed)
+	}
+}
+
+func Test_GivenSeveralDestroyReturnError_WhenStop_ThenReturnEveryError(t *testing.T) {
+	// Given
+	recorder := &DestroyRecorder{}
+	bike := NewBike()
+	bike.Add(Component{
+		ID:          "Database",
+		Constructor: func() *DatabaseComponent { return &DatabaseComponent{recorder: recorder} },
+		Destroy:     "CloseError",
+	})
+	bike.Add(Component{
+		ID: "Service",
+		Constructor: func(database *DatabaseComponent) *ServiceComponent {
+			return &ServiceComponent{recorder: recorder}
+		},
+		Scope:   Prototype,
+		Destroy: "CloseError",
+	})
+	container, _ := bike.Start()
+	_, _ = container.InstanceByID("Service")
+	_, _ = container.InstanceByID("Service")
+	// When
+	stopErr := container.Stop()
+	// Then
+	if stopErr == nil {
+		t.Errorf("Stop must return an error")
+		return
+	}
+	if stopErr.ErrorCode() != DestroyReturnError {
+		t.Errorf("Stop must return DestroyReturnError error code")
+	}
+	if len(stopErr.Errors()) != 3 {
+		t.Errorf("Stop must return an error by each Destroy failed, actual:%d", len(stopErr.Errors()))
+	}
+}
+
+func Test_GivenSingletonDependingOnCustomScope_WhenStart_ThenReturnInvalidScopeError(t *testing.T) {
+	// Given
+	bike := NewBike()
+	_ = bike.AddCustomScope(CustomScope, "name")
+	bike.Add(Component{Constructor: NewA, Scope: CustomScope})
+	bike.Add(Component{Constructor: NewB, Scope: Singleton})
+	// When
+	_, startErr := bike.Start()
+	// Then
+	if startErr == nil || startErr.ErrorCode() != InvalidScope {
+		t.Errorf("Start must return InvalidScope error")
+	}
+}
+
+func Test_GivenCustomScope_WhenResolveAndRemoveContextConcurrently_ThenReturnSameInstanceByContext(t *testing.T) {
+	// Given
+	bike := NewBike()
+	_ = bike.AddCustomScope(CustomScope, "name")
+	bike.Add(Component{Constructor: NewA, Scope: CustomScope})
+	bike.Add(Component{ID: "IdB", Constructor: NewB, Scope: CustomScope})
+	bike.Add(Component{Constructor: NewC, Scope: Prototype})
+	container, _ := bike.Start()
+	var wg sync.WaitGroup
+	errorsByContext := make(chan string, 100)
+	// When
+	for index := 0; index < 50; index++ {
+		idContext := fmt.Sprintf("id-%d", index%10)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			instanceC, errC := container.InstanceByTypeAndIDContext((*C)(nil), CustomScope, idContext)
+			instanceB, errB := container.InstanceByIDAndIDContext("IdB", CustomScope, idContext)
+			if errC != nil || errB != nil {
+				errorsByContext <- idContext
+				return
+			}
+			if instanceC.(*C).b != instanceB.(*B) {
+				errorsByContext <- idContext
+			}
+		}()
+	}
+	wg.Wait()
+	for index := 0; index < 10; index++ {
+		if err := container.RemoveContext(CustomScope, fmt.Sprintf("id-%d", index)); err != nil {
+			t.Errorf("RemoveContext must return nil error")
+		}
+	}
+	close(errorsByContext)
+	// Then
+	for idContext := range errorsByContext {
+		t.Errorf("Context %s must return same instance", idContext)
+	}
+	if stopErr := container.Stop(); stopErr != nil {
+		t.Errorf("Stop must return nil error")
+	}
+}
+
+type SlowDependency struct{}
+
+func NewSlowDependency() *SlowDependency {
+	time.Sleep(time.Millisecond)
+	return &SlowDependency{}
+}
+
+type ScopedConsumer struct {
+	a *A
+}
+
+func NewScopedConsumer(slow *SlowDependency, a *A) *ScopedConsumer {
+	return &ScopedConsumer{a: a}
+}
+
+func Test_GivenCustomScope_WhenResolveWhileRemoveContextOnSameContext_ThenReturnInstance(t *testing.T) {
+	// Given
+	bike := NewBike()
+	_ = bike.AddCustomScope(CustomScope, "name")
+	bike.Add(Component{Constructor: NewA, Scope: CustomScope})
+	bike.Add(Component{Constructor: NewScopedConsumer, Scope: CustomScope})
+	bike.Add(Component{Constructor: NewSlowDependency, Scope: Prototype})
+	container, _ := bike.Start()
+	errorsByContext := make(chan error, 200)
+	var wg sync.WaitGroup
+	// When
+	for resolver := 0; resolver < 2; resolver++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := 0; index < 100; index++ {
+				if _, err := container.InstanceByTypeAndIDContext((*ScopedConsumer)(nil), CustomScope, "id"); err != nil {
+					errorsByContext <- err
+				}
+			}
+		}()
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for index := 0; index < 100; index++ {
+			// The context may not exist yet
+			_ = container.RemoveContext(CustomScope, "id")
+			time.Sleep(time.Millisecond / 2)
+		}
+	}()
+	wg.Wait()
+	close(errorsByContext)
+	// Then
+	for err := range errorsByContext {
+		t.Errorf("InstanceByTypeAndIDContext must return nil error. Error:%s", err.Error())
+	}
+}
+
+func Test_GivenContextRemovedWhileWaitingForLock_WhenGetInstanceByType_ThenCreateInstanceOnNewContext(t *testing.T) {
+	// Given
+	bike := NewBike()
+	_ = bike.AddCustomScope(CustomScope, "name")
+	bike.Add(Component{Constructor: NewA, Scope: CustomScope})
+	container, _ := bike.Start()
+	resolution, unlock := container.lockContext(CustomScope, "id")
+	instances := make(chan interface{}, 1)
+	go func() {
+		instance, _ := container.InstanceByTypeAndIDContext((*A)(nil), CustomScope, "id")
+		instances <- instance
+	}()
+	time.Sleep(10 * time.Millisecond)
+	// When
+	container.contextsMutex.Lock()
+	delete(container.customScopeContexts[CustomScope], "id")
+	container.contextsMutex.Unlock()
+	resolution.context.removed = true
+	unlock()
+	instance := <-instances
+	// Then
+	newContext := container.context(CustomScope, "id")
+	if instance == nil || len(resolution.context.created) != 0 || newContext == resolution.context || len(newContext.created) != 1 {
+		t.Errorf("InstanceByTypeAndIDContext must create instance on a new context")
+	}
+}
+
+type ContextLookup struct {
+	a *A
+}
+
+func (_self *ContextLookup) Init(container *Container) error {
+	instance, err := container.InstanceByTypeAndIDContext((*A)(nil), CustomScope, "id")
+	if err != nil {
+		return err
+	}
+	_self.a = instance.(*A)
+	return nil
+}
+
+type ContextInitializer struct {
+	a *A
+}
+
+func (_self *ContextInitializer) PostConstruct(container *Container) error {
+	instance, err := container.InstanceByIDAndIDContext("IdA", CustomScope, "id")
+	if err != nil {
+		return err
+	}
+	_self.a = instance.(*A)
+	return nil
+}
+
+func Test_GivenPostConstructResolvingSameContext_WhenGetInstanceByType_ThenReturnInstanceWithDependency(t *testing.T) {
+	// Given
+	bike := NewBike()
+	_ = bike.AddCustomScope(CustomScope, "name")
+	bike.Add(Component{ID: "IdA", Constructor: NewA, Scope: CustomScope})
+	bike.Add(Component{Constructor: func() *ContextLookup { return &ContextLookup{} }, Scope: CustomScope, PostConstruct: "Init"})
+	bike.Add(Component{Constructor: func() *ContextInitializer { return &ContextInitializer{} }, Scope: CustomScope})
+	container, _ := bike.Start()
+	resolved := make(chan []interface{}, 1)
+	// When
+	go func() {
+		lookup, _ := container.InstanceByTypeAndIDContext((*ContextLookup)(nil), CustomScope, "id")
+		initializer, _ := container.InstanceByTypeAndIDContext((*ContextInitializer)(nil), CustomScope, "id")
+		a, _ := container.InstanceByTypeAndIDContext((*A)(nil), CustomScope, "id")
+		resolved <- []interface{}{lookup, initializer, a}
+	}()
+	// Then
+	select {
+	case instances := <-resolved:
+		lookup, _ := instances[0].(*ContextLookup)
+		initializer, _ := instances[1].(*ContextInitializer)
+		if lookup == nil || initializer == nil || lookup.a != instances[2] || initializer.a != instances[2] {
+			t.Errorf("PostConstruct must resolve instances of the context being resolved")
+		}
+	case <-time.After(time.Second):
+		t.Error("PostConstruct resolving the context being resolved must not block")
+	}
+}
+
+func Test_GivenCustomScopeWithMissingDependency_WhenGetInstanceByType_ThenReturnError(t *testing.T) {
+	// Given
+	bike := NewBike()
+	_ = bike.AddCustomScope(CustomScope, "name")
+	bike.Add(Component{Constructor: NewB, Scope: CustomScope})
+	container, _ := bike.Start()
+	// When
+	_, err := container.InstanceByTypeAndIDContext((*B)(nil), CustomScope, "id")
+	// Then
+	if err == nil || err.ErrorCode() != DependencyByTypeNotFound {
+		t.Errorf("InstanceByTypeAndIDContext must return DependencyByTypeNotFound error")
+	}
+}
+
+func Test_GivenCustomScopeWithDestroy_WhenRemoveContext_ThenDestroyInstancesInReverseCreationOrder(t *testing.T) {
+	// Given
+	recorder := &DestroyRecorder{}
+	bike := NewBike()
+	_ = bike.AddCustomScope(CustomScope, "name")
+	bike.Add(Component{
+		Constructor: func(database *DatabaseComponent) *ServiceComponent {
+			return &ServiceComponent{recorder: recorder}
+		},
+		Scope:   CustomScope,
+		Destroy: "Close",
+	})
+	bike.Add(Component{
+		Constructor: func() *DatabaseComponent { return &DatabaseComponent{recorder: recorder} },
+		Scope:       CustomScope,
+		Destroy:     "Close",
+	})
+	bike.Add(Component{Constructor: NewA, Scope: CustomScope})
+	container, _ := bike.Start()
+	_, _ = container.InstanceByTypeAndIDContext((*A)(nil), CustomScope, "id")
+	_, _ = container.InstanceByTypeAndIDContext((*ServiceComponent)(nil), CustomScope, "id")
+	// When
+	err := container.RemoveContext(CustomScope, "id")
+	// Then
+	if err != nil {
+		t.Errorf("RemoveContext must return nil error")
+	}
+	if len(recorder.destroyed) != 2 || recorder.destroyed[0] != "service" || recorder.destroyed[1] != "database" {
+		t.Errorf("RemoveContext must destroy instances in reverse creation order, actual:%v", recorder.destroyed)
+	}
+	if len(container.customScopeContexts[CustomScope]) != 0 {
+		t.Errorf("RemoveContext must remove context")
+	}
+}
+
+func Test_GivenCustomScopeDependingOnPrototype_WhenRemoveContext_ThenDestroyAndReleasePrototype(t *testing.T) {
+	// Given
+	recorder := &DestroyRecorder{}
+	bike := NewBike()
+	_ = bike.AddCustomScope(CustomScope, "name")
+	bike.Add(Component{
+		Constructor: func(database *DatabaseComponent) *ServiceComponent {
+			return &ServiceComponent{recorder: recorder}
+		},
+		Scope:   CustomScope,
+		Destroy: "Close",
+	})
+	bike.Add(Component{
+		Constructor: func() *DatabaseComponent { return &DatabaseComponent{recorder: recorder} },
+		Scope:       Prototype,
+		Destroy:     "Close",
+	})
+	container, _ := bike.Start()
+	_, _ = container.InstanceByTypeAndIDContext((*ServiceComponent)(nil), CustomScope, "id")
+	instantiated := container.Components()[1].Instantiated
+	// When
+	err := container.RemoveContext(CustomScope, "id")
+	// Then
+	if err != nil {
+		t.Errorf("RemoveContext must return nil error")
+	}
+	if len(recorder.destroyed) != 2 || recorder.destroyed[0] != "service" || recorder.destroyed[1] != "database" {
+		t.Errorf("RemoveContext must destroy prototypes created on context, actual:%v", recorder.destroyed)
+	}
+	if !instantiated || len(container.components[1].prototypeInstancesValue) != 0 {
+		t.Errorf("Prototypes created on context must be released by RemoveContext")
+	}
+	if stopErr := container.Stop(); stopErr != nil || len(recorder.destroyed) != 2 {
+		t.Errorf("Stop must not destroy prototypes created on a removed context")
+	}
+}
+
+func Test_GivenCustomScopeWithDestroyReturnError_WhenRemoveContext_ThenReturnEveryError(t *testing.T) {
+	// Given
+	recorder := &DestroyRecorder{}
+	bike := NewBike()
+	_ = bike.AddCustomScope(CustomScope, "name")
+	bike.Add(Component{
+		Constructor: func(database *DatabaseComponent) *ServiceComponent {
+			return &ServiceComponent{recorder: recorder}
+		},
+		Scope:   CustomScope,
+		Destroy: "CloseError",
+	})
+	bike.Add(Component{
+		Constructor: func() *DatabaseComponent { return &DatabaseComponent{recorder: recorder} },
+		Scope:       CustomScope,
+		Destroy:     "CloseError",
+	})
+	container, _ := bike.Start()
+	_, _ = container.InstanceByTypeAndIDContext((*ServiceComponent)(nil), CustomScope, "id")
+	// When
+	err := container.RemoveContext(CustomScope, "id")
+	// Then
+	if err == nil || err.ErrorCode() != DestroyReturnError {
+		t.Errorf("RemoveContext must return DestroyReturnError error")
+		return
+	}
+	if len(err.Errors()) != 2 {
+		t.Errorf("RemoveContext must return an error by each Destroy failed")
+	}
+	if _, errInstance := container.InstanceByTypeAndIDContext((*DatabaseComponent)(nil), CustomScope, "id"); errInstance != nil {
+		t.Errorf("InstanceByTypeAndIDContext must create a new context after remove")
+	}
+}
+
+type OtherStructComponent struct{}
+
+func (_self *OtherStructComponent) DoAnything() {}
+
+type InterfaceConsumer struct {
+	Dependency InterfaceComponent
+}
+
+func NewInterfaceConsumer(dependency InterfaceComponent) *InterfaceConsumer {
+	return &InterfaceConsumer{Dependency: dependency}
+}
+
+func newBikeWithTwoImplementations(primary bool) *Bike {
+	bike := NewBike()
+	bike.Add(Component{
+		ID:          "Struct",
+		Constructor: NewComponent,
+		Interfaces:  []any{(*InterfaceComponent)(nil)},
+		Primary:     primary,
+	})
+	bike.Add(Component{
+		ID:          "Other",
+		Constructor: func() *OtherStructComponent { return &OtherStructComponent{} },
+		Interfaces:  []any{(*InterfaceComponent)(nil), (*InterfaceComponent)(nil)},
+		Qualifier:   "other",
+	})
+	return bike
+}
+
+func Test_GivenTwoImplementationsWithoutPrimary_WhenStart_ThenReturnAmbiguousDependency(t *testing.T) {
+	// Given
+	bike := newBikeWithTwoImplementations(false)
+	bike.Add(Component{Constructor: NewInterfaceConsumer})
+	// When
+	_, startErr := bike.Start()
+	// Then
+	if startErr == nil || startErr.ErrorCode() != AmbiguousDependency {
+		t.Errorf("Start must return AmbiguousDependency error")
+	}
+}
+
+func Test_GivenTwoImplementationsWithPrimary_WhenStart_ThenInjectPrimary(t *testing.T) {
+	// Given
+	bike := newBikeWithTwoImplementations(true)
+	bike.Add(Component{Constructor: NewInterfaceConsumer})
+	// When
+	container, startErr := bike.Start()
+	// Then
+	if startErr != nil {
+		t.Errorf("Start must return nil error. Error:%s", startErr.Error())
+		return
+	}
+	consumer := MustResolve[*InterfaceConsumer](container)
+	if _, ok := consumer.Dependency.(*StructComponent); !ok {
+		t.Errorf("Start must inject primary component")
+	}
+}
+
+func Test_GivenTwoPrimaryImplementations_WhenInstanceByType_ThenReturnAmbiguousDependency(t *testing.T) {
+	// Given
+	bike := newBikeWithTwoImplementations(true)
+	bike.components[1].Primary = true
+	container, _ := bike.Start()
+	// When
+	_, err := container.InstanceByType((*InterfaceComponent)(nil))
+	// Then
+	if err == nil || err.ErrorCode() != AmbiguousDependency {
+		t.Errorf("InstanceByType must return AmbiguousDependency error")
+	}
+}
+
+func Test_GivenQualifierOnConstructorArgument_WhenStart_ThenInjectQualifiedComponent(t *testing.T) {
+	// Given
+	bike := newBikeWithTwoImplementations(false)
+	bike.Add(Component{ID: "ByQualifier", Constructor: NewInterfaceConsumer, Qualifiers: map[int]string{0: "other"}})
+	bike.Add(Component{ID: "ByID", Constructor: NewInterfaceConsumer, Qualifiers: map[int]string{0: "Struct"}})
+	// When
+	container, startErr := bike.Start()
+	// Then
+	if startErr != nil {
+		t.Errorf("Start must return nil error. Error:%s", startErr.Error())
+		return
+	}
+	if _, ok := MustResolveByID[*InterfaceConsumer](container, "ByQualifier").Dependency.(*OtherStructComponent); !ok {
+		t.Errorf("Start must inject component by qualifier")
+	}
+	if _, ok := MustResolveByID[*InterfaceConsumer](container, "ByID").Dependency.(*StructComponent); !ok {
+		t.Errorf("Start must inject component by id")
+	}
+}
+
+type QualifiedFieldComponent struct {
+	Dependency InterfaceComponent `bike:"qualifier=other"`
+}
+
+type AmbiguousFieldComponent struct {
+	Dependency InterfaceComponent `bike:"inject"`
+}
+
+func Test_GivenQualifierOnField_WhenStart_ThenInjectQualifiedComponent(t *testing.T) {
+	// Given
+	bike := newBikeWithTwoImplementations(false)
+	bike.Add(Component{Constructor: func() *QualifiedFieldComponent { return &QualifiedFieldComponent{} }})
+	// When
+	container, startErr := bike.Start()
+	// Then
+	if startErr != nil {
+		t.Errorf("Start must return nil error. Error:%s", startErr.Error())
+		return
+	}
+	if _, ok := MustResolve[*QualifiedFieldComponent](container).Dependency.(*OtherStructComponent); !ok {
+		t.Errorf("Start must inject field by qualifier")
+	}
+}
+
+func Test_GivenAmbiguousField_WhenStart_ThenReturnAmbiguousDependency(t *testing.T) {
+	// Given
+	bike := newBikeWithTwoImplementations(false)
+	bike.Add(Component{Constructor: func() *AmbiguousFieldComponent { return &AmbiguousFieldComponent{} }})
+	// When
+	_, startErr := bike.Start()
+	// Then
+	if startErr == nil || startErr.ErrorCode() != AmbiguousDependency {
+		t.Errorf("Start must return AmbiguousDependency error")
+	}
+}
+
+func Test_GivenQualifier_WhenInstanceByTypeAndQualifier_ThenReturnQualifiedComponent(t *testing.T) {
+	// Given
+	container, _ := newBikeWithTwoImplementations(false).Start()
+	// When
+	instance, err := container.InstanceByTypeAndQualifier((*InterfaceComponent)(nil), "other")
+	resolved, errResolve := ResolveQualified[InterfaceComponent](container, "Struct")
+	// Then
+	if err != nil || errResolve != nil {
+		t.Errorf("InstanceByTypeAndQualifier must return nil error")
+		return
+	}
+	if _, ok := instance.(*OtherStructComponent); !ok {
+		t.Errorf("InstanceByTypeAndQualifier must return component by qualifier")
+	}
+	if _, ok := resolved.(*StructComponent); !ok {
+		t.Errorf("ResolveQualified must return component by id")
+	}
+}
+
+func Test_GivenUnknownQualifier_WhenInstanceByTypeAndQualifier_ThenReturnDependencyByTypeNotFound(t *testing.T) {
+	// Given
+	container, _ := newBikeWithTwoImplementations(false).Start()
+	// When
+	_, err := container.InstanceByTypeAndQualifier((*OtherStructComponent)(nil), "unknown")
+	// Then
+	if err == nil || err.ErrorCode() != DependencyByTypeNotFound {
+		t.Errorf("InstanceByTypeAndQualifier must return DependencyByTypeNotFound error")
+	}
+}
+
+func Test_GivenQualifierOfInvalidArgument_WhenStart_ThenReturnInvalidQualifier(t *testing.T) {
+	// Given
+	bike := NewBike()
+	bike.Add(Component{Constructor: NewInterfaceConsumer, Qualifiers: map[int]string{1: "other"}})
+	// When
+	_, startErr := bike.Start()
+	// Then
+	if startErr == nil || startErr.ErrorCode() != InvalidQualifier {
+		t.Errorf("Start must return InvalidQualifier error")
+	}
+}
+
+type LazyCounter struct {
+	created int
+}
+
+func newLazyBike(counter *LazyCounter) *Bike {
+	bike := NewBike()
+	bike.Add(Component{
+		ID: "Lazy",
+		Constructor: func() *StructComponent {
+			counter.created++
+			return &StructComponent{}
+		},
+		Lazy:    true,
+		Destroy: "Stop",
+	})
+	return bike
+}
+
+func Test_GivenLazySingleton_WhenStart_ThenDoesNotCreateIt(t *testing.T) {
+	// Given
+	counter := &LazyCounter{}
+	bike := newLazyBike(counter)
+	// When
+	container, startErr := bike.Start()
+	// Then
+	if startErr != nil {
+		t.Errorf("Start must return nil error")
+	}
+	if counter.created != 0 {
+		t.Errorf("Start must not create lazy singleton")
+	}
+	if stopErr := container.Stop(); stopErr != nil || counter.created != 0 {
+		t.Errorf("Stop must not create lazy singleton")
+	}
+}
+
+func Test_GivenLazySingleton_WhenInstanceByTypeConcurrently_ThenCreateItOnce(t *testing.T) {
+	// Given
+	counter := &LazyCounter{}
+	container, _ := newLazyBike(counter).Start()
+	instances := make(chan *StructComponent, 10)
+	var wg sync.WaitGroup
+	// When
+	for index := 0; index < 10; index++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			instance, _ := Resolve[*StructComponent](container)
+			instances <- instance
+		}()
+	}
+	wg.Wait()
+	close(instances)
+	// Then
+	if counter.created != 1 {
+		t.Errorf("InstanceByType must create lazy singleton once, actual:%d", counter.created)
+	}
+	first := MustResolveByID[*StructComponent](container, "Lazy")
+	for instance := range instances {
+		if instance != first {
+			t.Errorf("InstanceByType must return same lazy singleton")
+		}
+	}
+	_ = container.Stop()
+	if !first.StopStatus {
+		t.Errorf("Stop must destroy created lazy singleton")
+	}
+}
+
+func Test_GivenBikeStartedAndStopped_WhenStartAgain_ThenCreateNewSingletons(t *testing.T) {
+	// Given
+	counter := &LazyCounter{}
+	bike := newLazyBike(counter)
+	bike.Add(Component{ID: "Eager", Constructor: func() *A {
+		counter.created++
+		return &A{}
+	}})
+	first, _ := bike.Start()
+	firstLazy := MustResolveByID[*StructComponent](first, "Lazy")
+	_ = first.Stop()
+	// When
+	second, startErr := bike.Start()
+	// Then
+	if startErr != nil {
+		t.Errorf("Start must return nil error")
+		return
+	}
+	if MustResolveByID[*StructComponent](second, "Lazy") == firstLazy || counter.created != 4 {
+		t.Errorf("Start must create singletons of each container, created:%d", counter.created)
+	}
+}
+
+func Test_GivenEagerSingletonDependingOnLazySingleton_WhenStart_ThenCreateLazySingleton(t *testing.T) {
+	// Given
+	bike := NewBike()
+	bike.Add(Component{Constructor: NewB})
+	bike.Add(Component{Constructor: NewA, Lazy: true})
+	// When
+	container, startErr := bike.Start()
+	// Then
+	if startErr != nil {
+		t.Errorf("Start must return nil error")
+		return
+	}
+	if MustResolve[*B](container).a != MustResolve[*A](container) {
+		t.Errorf("Start must inject lazy singleton")
+	}
+}
+
+func Test_GivenLazySingletonWithInvalidDependency_WhenInstanceByType_ThenReturnError(t *testing.T) {
+	// Given
+	bike := NewBike()
+	bike.Add(Component{Constructor: NewB, Lazy: true})
+	container, _ := bike.Start()
+	// When
+	_, err := container.InstanceByType((*B)(nil))
+	// Then
+	if err == nil || err.ErrorCode() != DependencyByTypeNotFound {
+		t.Errorf("InstanceByType must return DependencyByTypeNotFound error")
+	}
+}
+
+func Test_GivenLazyPrototype_WhenStart_ThenReturnLazyWithScopeDifferentToSingleton(t *testing.T) {
+	// Given
+	bike := NewBike()
+	bike.Add(Component{Constructor: NewA, Scope: Prototype, Lazy: true})
+	// When
+	_, startErr := bike.Start()
+	// Then
+	if startErr == nil || startErr.ErrorCode() != LazyWithScopeDifferentToSingleton {
+		t.Errorf("Start must return LazyWithScopeDifferentToSingleton error")
+	}
+}
+
+func Test_GivenLazySingletonWithPostStart_WhenStart_ThenReturnLazyWithPostStart(t *testing.T) {
+	// Given
+	withPostStart := NewBike()
+	withPostStart.Add(Component{Constructor: NewComponent, PostStart: "PostInit", Lazy: true})
+	withStarter := NewBike()
+	withStarter.Add(Component{Constructor: NewLifecycleComponent, Lazy: true})
+	// When
+	_, postStartErr := withPostStart.Start()
+	_, starterErr := withStarter.Start()
+	// Then
+	if postStartErr == nil || postStartErr.ErrorCode() != LazyWithPostStart {
+		t.Errorf("Start must return LazyWithPostStart error")
+	}
+	if starterErr == nil || starterErr.ErrorCode() != LazyWithPostStart {
+		t.Errorf("Start must return LazyWithPostStart error for Starter")
+	}
+}
+
+func newParentContainer(t *testing.T) *Container {
+	bike := NewBike()
+	_ = bike.AddCustomScope(CustomScope, "name")
+	bike.Add(Component{ID: "A", Constructor: NewA})
+	bike.Add(Component{ID: "Struct", Constructor: NewComponent, Interfaces: []any{(*InterfaceComponent)(nil)}, Destroy: "Stop"})
+	bike.Add(Component{ID: "Prototype", Constructor: func() *OtherStructComponent { return &OtherStructComponent{} }, Scope: Prototype})
+	container, err := bike.Start()
+	if err != nil {
+		t.Errorf("Start must return nil error. Error:%s", err.Error())
+	}
+	return container
+}
+
+func Test_GivenChildContainer_WhenInstanceByType_ThenInjectSingletonsOfParent(t *testing.T) {
+	// Given
+	parent := newParentContainer(t)
+	// When
+	child, err := parent.NewChild(Component{Constructor: NewB, Scope: CustomScope})
+	// Then
+	if err != nil {
+		t.Errorf("NewChild must return nil error. Error:%s", err.Error())
+		return
+	}
+	b, errB := ResolveInContext[*B](child, CustomScope, "id")
+	if errB != nil || b.a != MustResolve[*A](parent) {
+		t.Errorf("Child must inject singletons of parent")
+	}
+	a, errA := ResolveByID[*A](child, "A")
+	if errA != nil || a != MustResolve[*A](parent) {
+		t.Errorf("Child must return singletons of parent by id")
+	}
+}
+
+func Test_GivenChildContainerOverridingComponent_WhenInstanceByType_ThenReturnComponentOfChild(t *testing.T) {
+	// Given
+	parent := newParentContainer(t)
+	child, _ := parent.NewChild(Component{
+		ID:          "Struct",
+		Constructor: func() *OtherStructComponent { return &OtherStructComponent{} },
+		Interfaces:  []any{(*InterfaceComponent)(nil)},
+	})
+	// When
+	childInstance := MustResolve[InterfaceComponent](child)
+	parentInstance := MustResolve[InterfaceComponent](parent)
+	// Then
+	if _, ok := childInstance.(*OtherStructComponent); !ok {
+		t.Errorf("Child must return its own component")
+	}
+	if _, ok := parentInstance.(*StructComponent); !ok {
+		t.Errorf("Parent must return its own component")
+	}
+	if _, ok := MustResolveByID[InterfaceComponent](child, "Struct").(*OtherStructComponent); !ok {
+		t.Errorf("Child must return its own component by id")
+	}
+}
+
+func Test_GivenChildContainer_WhenStop_ThenDestroyOnlyComponentsOfChild(t *testing.T) {
+	// Given
+	parent := newParentContainer(t)
+	child, _ := parent.NewChild(Component{
+		Constructor: func(inter InterfaceComponent) *StructComponent { return &StructComponent{} },
+		Destroy:     "Stop",
+	})
+	childInstance := MustResolve[*StructComponent](child)
+	// When
+	err := child.Stop()
+	// Then
+	if err != nil {
+		t.Errorf("Stop must return nil error")
+	}
+	if !childInstance.StopStatus {
+		t.Errorf("Stop must destroy components of child")
+	}
+	if MustResolve[*StructComponent](parent).StopStatus {
+		t.Errorf("Stop of child must not destroy components of parent")
+	}
+}
+
+func Test_GivenPrototypeOnParent_WhenInstanceOnChild_ThenReturnNotFoundError(t *testing.T) {
+	// Given
+	parent := newParentContainer(t)
+	child, _ := parent.NewChild()
+	// When
+	_, errByType := child.InstanceByType((*OtherStructComponent)(nil))
+	_, errByID := child.InstanceByID("Prototype")
+	// Then
+	if errByType == nil || errByType.ErrorCode() != DependencyByTypeNotFound {
+		t.Errorf("InstanceByType must return DependencyByTypeNotFound error")
+	}
+	if errByID == nil || errByID.ErrorCode() != DependencyByIDNotFound {
+		t.Errorf("InstanceByID must return DependencyByIDNotFound error")
+	}
+}
+
+func Test_GivenLazySingletonOnParent_WhenInstanceOnChild_ThenParentCreatesIt(t *testing.T) {
+	// Given
+	bike := NewBike()
+	bike.Add(Component{Constructor: NewComponent})
+	bike.Add(Component{Constructor: func(dependency *StructComponent) *InterfaceConsumer {
+		return &InterfaceConsumer{Dependency: dependency}
+	}, Lazy: true})
+	parent, _ := bike.Start()
+	child, _ := parent.NewChild(Component{Constructor: NewComponent})
+	// When
+	consumer, err := Resolve[*InterfaceConsumer](child)
+	// Then
+	if err != nil || consumer != MustResolve[*InterfaceConsumer](parent) {
+		t.Errorf("Child must return lazy singleton of parent")
+	}
+	if consumer.Dependency != MustResolve[*StructComponent](parent) {
+		t.Errorf("Lazy singleton of parent must be created with components of parent")
+	}
+}
+
+func Test_GivenInvalidComponent_WhenNewChild_ThenReturnError(t *testing.T) {
+	// Given
+	parent := newParentContainer(t)
+	// When
+	_, err := parent.NewChild(Component{})
+	// Then
+	if err == nil || err.ErrorCode() != ComponentConstructorNull {
+		t.Errorf("NewChild must return ComponentConstructorNull error")
+	}
+}
@@ -0,0 +1,19 @@
+package db
+
+import "github.com/kybsa/bike"
+
+// NewModule return a module with PostgresComponent, exports *PostgresComponent.
+// *config.SimpleConfig must be exported by an imported module or added out of modules
+func NewModule(imports ...*bike.Module) *bike.Module {
+	return &bike.Module{
+		Name: "db",
+		Components: []bike.Component{
+			{
+				ID:          "PostgresComponent",
+				Constructor: NewPostgresComponent,
+			},
+		},
+		Imports: imports,
+		Exports: []any{(*PostgresComponent)(nil)},
+	}
+}
@@ -0,0 +1,58 @@
+package db
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kybsa/bike"
+	"github.com/kybsa/bike/config"
+	"gorm.io/gorm"
+)
+
+func TestNewModule_GivenConfigModule_WhenStart_ThenExportPostgresComponent(t *testing.T) {
+	// Given
+	sqlOpen = func(dialector gorm.Dialector, opt ...gorm.Option) (*gorm.DB, error) {
+		return &gorm.DB{}, nil
+	}
+	configModule := config.NewModule(&config.SimpleConfig{
+		MapConfig: map[string]string{"PostgresComponent.Dsn": "ca"},
+	})
+	bk := bike.NewBike()
+	bk.AddModule(NewModule(configModule))
+	// When
+	container, err := bk.Start()
+	// Then
+	if err != nil {
+		t.Errorf("Start must return nil error. Error:%s", err.Error())
+		return
+	}
+	postgresComponent, errPostgres := bike.Resolve[*PostgresComponent](container)
+	if errPostgres != nil || postgresComponent.DB() == nil {
+		t.Error("Module must export PostgresComponent")
+	}
+}
+
+type DriverError struct{}
+
+func (_self *DriverError) Error() string {
+	return "password authentication failed"
+}
+
+func TestNewModule_GivenOpenError_WhenStart_ThenErrorsAsReturnDriverError(t *testing.T) {
+	// Given
+	sqlOpen = func(dialector gorm.Dialector, opt ...gorm.Option) (*gorm.DB, error) {
+		return nil, &DriverError{}
+	}
+	configModule := config.NewModule(&config.SimpleConfig{
+		MapConfig: map[string]string{"PostgresComponent.Dsn": "ca"},
+	})
+	bk := bike.NewBike()
+	bk.AddModule(NewModule(configModule))
+	// When
+	_, err := bk.Start()
+	// Then
+	var driverError *DriverError
+	if !errors.As(err, &driverError) {
+		t.Error("errors.As must return error of driver")
+	}
+}
@@ -0,0 +1,88 @@
+package bike
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+)
+
+// validateDecorator check decorator is a function with first argument of a pointer or interface type
+// and one return value of the same type
+func validateDecorator(decorator any) *Error {
+	decoratorType := reflect.TypeOf(decorator)
+	if decoratorType == nil || decoratorType.Kind() != reflect.Func || decoratorType.NumIn() == 0 || decoratorType.NumOut() != 1 ||
+		decoratorType.Out(0) != decoratorType.In(0) ||
+		(decoratorType.In(0).Kind() != reflect.Pointer && decoratorType.In(0).Kind() != reflect.Interface) {
+		return &Error{
+			messageError: fmt.Sprintf("Invalid decorator:[%v], decorator must be a function func(inner T, dependencies...) T of a pointer or interface type T", decoratorType),
+			errorCode:    InvalidDecorator}
+	}
+	return nil
+}
+
+// decoratorsOf return decorators of component in registration order, a decorator of type T decorates
+// every component registered by type T
+func (_self *Container) decoratorsOf(component *Component) []any {
+	decorators := make([]any, 0)
+	for _, decorator := range _self.decorators {
+		decoratedType := reflect.TypeOf(decorator).In(0)
+		for _, componentType := range component.types() {
+			if componentType == decoratedType {
+				decorators = append(decorators, decorator)
+				break
+			}
+		}
+	}
+	return decorators
+}
+
+// validateDecoratorsOf check every decorator of component decorates the same type, a decorator of an interface
+// returns a value a decorator of the concrete type can't wrap
+func (_self *Container) validateDecoratorsOf(component *Component) *Error {
+	var decoratedType reflect.Type
+	for _, decorator := range _self.decoratorsOf(component) {
+		_type := reflect.TypeOf(decorator).In(0)
+		if decoratedType != nil && _type != decoratedType {
+			return &Error{
+				messageError: fmt.Sprintf("Error on Component %s. Decorators of types %s and %s can't be chained, every decorator of a component must decorate the same type", componentDescription(component), decoratedType.String(), _type.String()),
+				errorCode:    InvalidDecorator,
+				componentID:  component.ID}
+		}
+		decoratedType = _type
+	}
+	return nil
+}
+
+// decorate return instance wrapped by decorators of component, the first decorator added wraps the instance.
+// The lock of context must be held by caller
+func (_self *Container) decorate(component *Component, instanceValue *reflect.Value, resolution *resolution) (*reflect.Value, *Error) {
+	decorated := *instanceValue
+	for _, decorator := range _self.decoratorsOf(component) {
+		decoratorType := reflect.TypeOf(decorator)
+		args := make([]reflect.Value, decoratorType.NumIn())
+		args[0] = decorated
+		for i := 1; i < decoratorType.NumIn(); i++ {
+			inputType := decoratorType.In(i)
+			inputArg, err := _self.resolveByType(inputType, "", component.module, resolution)
+			if err != nil {
+				return nil, &Error{
+					messageError:   fmt.Sprintf("Error on Component %s. Error to get dependency: [%s] required by Decorator:[%s]. %s", componentDescription(component), getTypeName(inputType), getDecoratorName(decorator), err.Error()),
+					errorCode:      err.ErrorCode(),
+					cause:          err,
+					dependencyType: inputType,
+					componentID:    component.ID}
+			}
+			args[i] = reflect.ValueOf(inputArg)
+		}
+		if err := callRecovering(component, ConstructorPanicked, fmt.Sprintf("Decorator:[%s]", getDecoratorName(decorator)), func() {
+			decorated = reflect.ValueOf(decorator).Call(args)[0]
+		}); err != nil {
+			return nil, err
+		}
+	}
+	return &decorated, nil
+}
+
+func getDecoratorName(decorator any) string {
+	return runtime.FuncForPC(reflect.ValueOf(decorator).Pointer()).Name()
+}
@@ -0,0 +1,292 @@
+package bike
+
+import (
+	"strings"
+	"testing"
+)
+
+type QueryCounter struct {
+	queries int
+}
+
+func NewQueryCounter() *QueryCounter {
+	return &QueryCounter{}
+}
+
+type CachedRepository struct {
+	inner Repository
+}
+
+func (_self *CachedRepository) Find() string {
+	return "cached(" + _self.inner.Find() + ")"
+}
+
+type CountedRepository struct {
+	inner   Repository
+	counter *QueryCounter
+}
+
+func (_self *CountedRepository) Find() string {
+	_self.counter.queries++
+	return "counted(" + _self.inner.Find() + ")"
+}
+
+func cacheDecorator(inner Repository) Repository {
+	return &CachedRepository{inner: inner}
+}
+
+func countDecorator(inner Repository, counter *QueryCounter) Repository {
+	return &CountedRepository{inner: inner, counter: counter}
+}
+
+type RepositoryConsumer struct {
+	Repository Repository `bike:"inject"`
+}
+
+func NewRepositoryConsumer() *RepositoryConsumer {
+	return &RepositoryConsumer{}
+}
+
+func newDecoratedBike(scope Scope) *Bike {
+	bike := NewBike()
+	_ = bike.AddCustomScope(CustomScope, "custom")
+	bike.Add(Component{ID: "RepositoryHelper", Constructor: NewRepositoryHelper})
+	bike.Add(Component{
+		ID:          "PostgresRepository",
+		Constructor: NewPostgresRepository,
+		Interfaces:  []any{(*Repository)(nil)},
+		Scope:       scope,
+	})
+	bike.Add(Component{Constructor: NewQueryCounter})
+	bike.Add(Component{Constructor: NewRepositoryConsumer, Scope: scope})
+	bike.AddDecorator(cacheDecorator)
+	bike.AddDecorator(countDecorator)
+	return bike
+}
+
+func TestAddDecorator_GivenDecorators_WhenResolve_ThenReturnDecoratedInstanceInOrder(t *testing.T) {
+	// Given
+	bike := newDecoratedBike(Singleton)
+	// When
+	container, err := bike.Start()
+	// Then
+	if err != nil {
+		t.Errorf("Start must return nil error. Error:%s", err.Error())
+		return
+	}
+	byType, _ := Resolve[Repository](container)
+	byID, _ := ResolveByID[Repository](container, "PostgresRepository")
+	consumer, _ := Resolve[*RepositoryConsumer](container)
+	if byType.Find() != "counted(cached(postgres))" {
+		t.Errorf("Decorators must be applied in order, actual:%s", byType.Find())
+	}
+	if byID != byType || consumer.Repository != byType {
+		t.Error("Every consumer must receive the decorated instance")
+	}
+	counter, _ := Resolve[*QueryCounter](container)
+	if counter.queries != 1 {
+		t.Error("Dependencies of decorator must be resolved from container")
+	}
+}
+
+func TestAddDecorator_GivenDecoratedComponent_WhenResolveByComponentType_ThenReturnUndecoratedInstance(t *testing.T) {
+	for _, scope := range []Scope{Singleton, Prototype, CustomScope} {
+		// Given
+		bike := newDecoratedBike(scope)
+		container, _ := bike.Start()
+		// When
+		byType, err := ResolveInContext[*PostgresRepository](container, CustomScope, "id")
+		collection, errCollection := ResolveInContext[[]*PostgresRepository](container, CustomScope, "id")
+		decorated, _ := ResolveInContext[Repository](container, CustomScope, "id")
+		// Then
+		if err != nil || errCollection != nil {
+			t.Errorf("Resolve must return nil error on scope %s, actual:%v %v", scope.String(), err, errCollection)
+			continue
+		}
+		if byType.Find() != "postgres" || len(collection) != 1 || collection[0].Find() != "postgres" {
+			t.Errorf("Resolve by type of component must return undecorated instance on scope %s", scope.String())
+		}
+		if decorated.Find() != "counted(cached(postgres))" {
+			t.Errorf("Resolve by decorated type must return decorated instance on scope %s", scope.String())
+		}
+		if scope != Prototype && byType != decorated.(*CountedRepository).inner.(*CachedRepository).inner {
+			t.Errorf("Undecorated instance must be the instance wrapped by decorators on scope %s", scope.String())
+		}
+	}
+}
+
+func TestAddDecorator_GivenPrototypeAndCustomScope_WhenResolve_ThenReturnDecoratedInstance(t *testing.T) {
+	for _, scope := range []Scope{Prototype, CustomScope} {
+		// Given
+		bike := newDecoratedBike(scope)
+		container, err := bike.Start()
+		if err != nil {
+			t.Errorf("Start must return nil error. Error:%s", err.Error())
+			continue
+		}
+		// When
+		consumer, errConsumer := ResolveInContext[*RepositoryConsumer](container, scope, "id")
+		repositories, errCollection := ResolveInContext[[]Repository](container, scope, "id")
+		// Then
+		if errConsumer != nil || consumer.Repository.Find() != "counted(cached(postgres))" {
+			t.Errorf("Consumer must receive decorated instance on scope %s", scope.String())
+		}
+		if errCollection != nil || repositories[0].Find() != "counted(cached(postgres))" {
+			t.Errorf("Collection must contain decorated instance on scope %s", scope.String())
+		}
+	}
+}
+
+func TestAddDecorator_GivenDecoratedComponentWithDestroy_WhenStop_ThenDestroyInnerInstance(t *testing.T) {
+	// Given
+	bike := NewBike()
+	_ = bike.AddCustomScope(CustomScope, "custom")
+	for _, scope := range []Scope{Singleton, Prototype, CustomScope} {
+		bike.Add(Component{Constructor: NewConnectionStub, Interfaces: []any{(*Connection)(nil)}, Scope: scope, Destroy: "Close"})
+	}
+	bike.AddDecorator(func(inner Connection) Connection {
+		return &RecordedConnection{inner: inner}
+	})
+	container, _ := bike.Start()
+	connections, _ := ResolveInContext[[]Connection](container, CustomScope, "id")
+	// When
+	errRemove := container.RemoveContext(CustomScope, "id")
+	errStop := container.Stop()
+	// Then
+	if errRemove != nil || errStop != nil {
+		t.Error("Destroy must be called on instance created by constructor")
+		return
+	}
+	for _, connection := range connections {
+		if !connection.(*RecordedConnection).inner.(*ConnectionStub).closed {
+			t.Error("Destroy must be called on every instance")
+		}
+	}
+}
+
+type RecordedConnection struct {
+	inner Connection
+}
+
+func (_self *RecordedConnection) Close() error {
+	return _self.inner.Close()
+}
+
+func NewConnectionStub() *ConnectionStub {
+	return &ConnectionStub{}
+}
+
+func TestAddDecorator_GivenInvalidDecorator_WhenStart_ThenReturnInvalidDecorator(t *testing.T) {
+	// Given
+	testCases := map[string]any{
+		"nil":                nil,
+		"no function":        "decorator",
+		"no arguments":       func() Repository { return nil },
+		"two return values":  func(inner Repository) (Repository, error) { return inner, nil },
+		"different type":     func(inner Repository) *PostgresRepository { return nil },
+		"no pointer type":    func(inner string) string { return inner },
+		"no return values":   func(inner Repository) {},
+		"other return value": func(inner *PostgresRepository) Repository { return inner },
+	}
+	for name, decorator := range testCases {
+		bike := NewBike()
+		bike.AddDecorator(decorator)
+		// When
+		_, err := bike.Start()
+		// Then
+		if err == nil || err.ErrorCode() != InvalidDecorator {
+			t.Errorf("Start must return InvalidDecorator error with %s decorator", name)
+		}
+	}
+}
+
+func TestAddDecorator_GivenDecoratorsOfInterfaceAndConcreteType_WhenStartAndValidate_ThenReturnInvalidDecorator(t *testing.T) {
+	// Given
+	bike := NewBike()
+	bike.Add(Component{ID: "RepositoryHelper", Constructor: NewRepositoryHelper})
+	bike.Add(Component{ID: "PostgresRepository", Constructor: NewPostgresRepository, Interfaces: []any{(*Repository)(nil)}})
+	bike.AddDecorator(cacheDecorator)
+	bike.AddDecorator(func(inner *PostgresRepository) *PostgresRepository { return inner })
+	// When
+	_, startErr := bike.Start()
+	validateErr := bike.Validate()
+	// Then
+	if startErr == nil || startErr.ErrorCode() != InvalidDecorator || startErr.ComponentID() != "PostgresRepository" {
+		t.Errorf("Start must return InvalidDecorator error, actual:%v", startErr)
+	}
+	if validateErr == nil || len(validateErr.Errors()) != 1 || validateErr.Errors()[0].ErrorCode() != InvalidDecorator {
+		t.Errorf("Validate must return InvalidDecorator error, actual:%v", validateErr)
+	}
+}
+
+func TestAddDecorator_GivenDependencyNotFound_WhenStart_ThenReturnError(t *testing.T) {
+	// Given
+	bike := NewBike()
+	bike.Add(Component{ID: "Repository", Constructor: NewPostgresRepository, Interfaces: []any{(*Repository)(nil)}})
+	bike.Add(Component{Constructor: NewRepositoryHelper})
+	bike.AddDecorator(countDecorator)
+	// When
+	_, err := bike.Start()
+	// Then
+	if err == nil || err.ErrorCode() != DependencyByTypeNotFound {
+		t.Error("Start must return DependencyByTypeNotFound error")
+		return
+	}
+	if !strings.Contains(err.Error(), "required by Decorator:[github.com/kybsa/bike.countDecorator]") {
+		t.Errorf("Error must contain decorator name. Error:%s", err.Error())
+	}
+}
+
+func TestAddDecorator_GivenDependencyNotFoundOnPrototype_WhenResolve_ThenReturnError(t *testing.T) {
+	for _, scope := range []Scope{Prototype, CustomScope} {
+		// Given
+		bike := NewBike()
+		_ = bike.AddCustomScope(CustomScope, "custom")
+		bike.Add(Component{Constructor: NewPostgresRepository, Interfaces: []any{(*Repository)(nil)}, Scope: scope})
+		bike.Add(Component{Constructor: NewRepositoryHelper})
+		bike.AddDecorator(countDecorator)
+		container, _ := bike.Start()
+		// When
+		_, err := ResolveInContext[Repository](container, scope, "id")
+		// Then
+		if err == nil || err.ErrorCode() != DependencyByTypeNotFound {
+			t.Errorf("Resolve must return DependencyByTypeNotFound error on scope %s", scope.String())
+		}
+	}
+}
+
+func TestAddDecorator_GivenAmbiguousDependency_WhenStart_ThenReturnAmbiguousDependency(t *testing.T) {
+	// Given
+	bike := NewBike()
+	bike.Add(Component{Constructor: NewPostgresRepository, Interfaces: []any{(*Repository)(nil)}})
+	bike.Add(Component{Constructor: NewRepositoryHelper})
+	bike.Add(Component{Constructor: NewQueryCounter})
+	bike.Add(Component{Constructor: NewQueryCounter})
+	bike.AddDecorator(countDecorator)
+	// When
+	_, err := bike.Start()
+	// Then
+	if err == nil || err.ErrorCode() != AmbiguousDependency {
+		t.Error("Start must return AmbiguousDependency error")
+	}
+}
+
+func TestAddDecorator_GivenParentWithDecorator_WhenNewChild_ThenDecorateComponentsOfChild(t *testing.T) {
+	// Given
+	bike := NewBike()
+	bike.AddDecorator(cacheDecorator)
+	parent, _ := bike.Start()
+	// When
+	child, err := parent.NewChild(
+		Component{Constructor: NewPostgresRepository, Interfaces: []any{(*Repository)(nil)}},
+		Component{Constructor: NewRepositoryHelper})
+	// Then
+	if err != nil {
+		t.Errorf("NewChild must return nil error. Error:%s", err.Error())
+		return
+	}
+	repository, _ := Resolve[Repository](child)
+	if repository.Find() != "cached(postgres)" {
+		t.Error("Decorators of parent must decorate components of child")
+	}
+}
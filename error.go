@@ -1,5 +1,12 @@
 package bike
 
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
 // ErrorCode type to enum error codes
 type ErrorCode uint8
 
@@ -30,12 +37,115 @@ const (
 	PostConstructReturnError ErrorCode = 12
 	// DuplicateScope error when a scope exist
 	DuplicateScope ErrorCode = 13
+	// CircularDependency error when constructors of components depend on each other in a loop
+	CircularDependency ErrorCode = 14
+	// DestroyReturnError error when a Destroy method return an error
+	DestroyReturnError ErrorCode = 15
+	// InstanceTypeMismatch error when an instance isn't of the requested type
+	InstanceTypeMismatch ErrorCode = 16
+	// InvalidInjectTag error when a field has an invalid bike tag or the field is unexported
+	InvalidInjectTag ErrorCode = 17
+	// AmbiguousDependency error when several components are registered by a type and none is primary
+	AmbiguousDependency ErrorCode = 18
+	// InvalidQualifier error when a qualifier reference an invalid constructor argument
+	InvalidQualifier ErrorCode = 19
+	// LazyWithScopeDifferentToSingleton error when a component is Lazy and Scope is different to Singleton
+	LazyWithScopeDifferentToSingleton ErrorCode = 20
+	// InvalidModuleExport error when a module export a type not provided by its components
+	InvalidModuleExport ErrorCode = 21
+	// InvalidInstance error when an instance registered by AddInstance isn't a not nil pointer
+	InvalidInstance ErrorCode = 22
+	// InvalidDecorator error when a decorator isn't a function func(inner T, dependencies...) T
+	InvalidDecorator ErrorCode = 23
+	// DuplicateComponentID error when several components are registered with the same ID
+	DuplicateComponentID ErrorCode = 24
+	// InterfaceNotImplemented error when type returned by constructor doesn't implement an entry of Interfaces
+	InterfaceNotImplemented ErrorCode = 25
+	// InvalidConfiguration error returned by Bike.Validate listing every error found
+	InvalidConfiguration ErrorCode = 26
+	// PostStartReturnError error when a PostStart method or a Starter component return an error
+	PostStartReturnError ErrorCode = 27
+	// PostStartPanicked error when a PostStart method or a Starter component panics
+	PostStartPanicked ErrorCode = 28
+	// StartTimeout error when a PostStart method or a Starter component doesn't finish before start timeout
+	StartTimeout ErrorCode = 29
+	// ConstructorPanicked error when a constructor or a decorator panics
+	ConstructorPanicked ErrorCode = 30
+	// PostConstructPanicked error when a PostConstruct method or an Initializer component panics
+	PostConstructPanicked ErrorCode = 31
+	// DestroyPanicked error when a Destroy method or a Disposer component panics
+	DestroyPanicked ErrorCode = 32
+	// RunnerReturnError error when a Runner component return an error or Container.Run fails
+	RunnerReturnError ErrorCode = 33
+	// RunnerPanicked error when a Runner component panics
+	RunnerPanicked ErrorCode = 34
+	// ShutdownTimeout error when a Runner component or a Destroy method doesn't finish before shutdown timeout
+	ShutdownTimeout ErrorCode = 35
+	// LazyWithPostStart error when a Lazy component has PostStart or implements Starter
+	LazyWithPostStart ErrorCode = 36
 )
 
+var mapErrorCodeString = map[ErrorCode]string{
+	DependencyByIDNotFound:                   "DependencyByIDNotFound",
+	DependencyByTypeNotFound:                 "DependencyByTypeNotFound",
+	InvalidScope:                             "InvalidScope",
+	InvalidNumArgOnPostConstruct:             "InvalidNumArgOnPostConstruct",
+	InvalidNumArgOnDestroy:                   "InvalidNumArgOnDestroy",
+	InvalidNumberOfReturnValuesOnConstructor: "InvalidNumberOfReturnValuesOnConstructor",
+	ConstructorReturnNoPointerValue:          "ConstructorReturnNoPointerValue",
+	ComponentConstructorNull:                 "ComponentConstructorNull",
+	ConstructorReturnNotNilError:             "ConstructorReturnNotNilError",
+	ConstructorLastReturnValueIsNotError:     "ConstructorLastReturnValueIsNotError",
+	PostStartWithScopeDifferentToSingleton:   "PostStartWithScopeDifferentToSingleton",
+	PostConstructReturnError:                 "PostConstructReturnError",
+	DuplicateScope:                           "DuplicateScope",
+	CircularDependency:                       "CircularDependency",
+	DestroyReturnError:                       "DestroyReturnError",
+	InstanceTypeMismatch:                     "InstanceTypeMismatch",
+	InvalidInjectTag:                         "InvalidInjectTag",
+	AmbiguousDependency:                      "AmbiguousDependency",
+	InvalidQualifier:                         "InvalidQualifier",
+	LazyWithScopeDifferentToSingleton:        "LazyWithScopeDifferentToSingleton",
+	InvalidModuleExport:                      "InvalidModuleExport",
+	InvalidInstance:                          "InvalidInstance",
+	InvalidDecorator:                         "InvalidDecorator",
+	DuplicateComponentID:                     "DuplicateComponentID",
+	InterfaceNotImplemented:                  "InterfaceNotImplemented",
+	InvalidConfiguration:                     "InvalidConfiguration",
+	PostStartReturnError:                     "PostStartReturnError",
+	PostStartPanicked:                        "PostStartPanicked",
+	StartTimeout:                             "StartTimeout",
+	ConstructorPanicked:                      "ConstructorPanicked",
+	PostConstructPanicked:                    "PostConstructPanicked",
+	DestroyPanicked:                          "DestroyPanicked",
+	RunnerReturnError:                        "RunnerReturnError",
+	RunnerPanicked:                           "RunnerPanicked",
+	ShutdownTimeout:                          "ShutdownTimeout",
+	LazyWithPostStart:                        "LazyWithPostStart",
+}
+
+// String return name of error code
+func (_self ErrorCode) String() string {
+	if name, ok := mapErrorCodeString[_self]; ok {
+		return name
+	}
+	return fmt.Sprintf("ErrorCode(%d)", uint8(_self))
+}
+
+// Error return name of error code, an ErrorCode can be the target of errors.Is
+func (_self ErrorCode) Error() string {
+	return _self.String()
+}
+
 // Error struct with error info
 type Error struct {
-	messageError string
-	errorCode    ErrorCode
+	messageError   string
+	errorCode      ErrorCode
+	errors         []*Error
+	stack          string
+	cause          error
+	componentID    string
+	dependencyType reflect.Type
 }
 
 // Error return message about error
@@ -47,3 +157,86 @@ func (_self *Error) Error() string {
 func (_self *Error) ErrorCode() ErrorCode {
 	return _self.errorCode
 }
+
+// Errors return errors aggregated on this error
+func (_self *Error) Errors() []*Error {
+	return _self.errors
+}
+
+// Stack return stack trace of the panic that caused the error or caused its dependency error,
+// empty if the error wasn't caused by a panic
+func (_self *Error) Stack() string {
+	var cause *Error
+	if len([]rune(_self.stack)) == 0 && errors.As(_self.cause, &cause) {
+		return cause.Stack()
+	}
+	return _self.stack
+}
+
+// Unwrap return the error that caused this error: the error returned by a constructor or a lifecycle method,
+// or the error of a dependency
+func (_self *Error) Unwrap() error {
+	return _self.cause
+}
+
+// Is return true when target is the ErrorCode of this error or of an aggregated error
+func (_self *Error) Is(target error) bool {
+	if errorCode, ok := target.(ErrorCode); ok && errorCode == _self.errorCode {
+		return true
+	}
+	for _, err := range _self.errors {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As find the first aggregated error that matches target
+func (_self *Error) As(target any) bool {
+	for _, err := range _self.errors {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// ComponentID return ID of component that caused the error, empty if the error isn't about a component
+func (_self *Error) ComponentID() string {
+	return _self.componentID
+}
+
+// DependencyType return type of dependency that caused the error, nil if the error isn't about a dependency
+func (_self *Error) DependencyType() reflect.Type {
+	return _self.dependencyType
+}
+
+// Path return IDs of components being resolved when the error happened, from the component required
+// to the component that caused the error
+func (_self *Error) Path() []string {
+	path := make([]string, 0)
+	if len([]rune(_self.componentID)) > 0 {
+		path = append(path, _self.componentID)
+	}
+	var cause *Error
+	if errors.As(_self.cause, &cause) {
+		path = append(path, cause.Path()...)
+	}
+	return path
+}
+
+// joinErrors return an error listing every error or nil if errors is empty
+func joinErrors(errorCode ErrorCode, message string, errors []*Error) *Error {
+	if len(errors) == 0 {
+		return nil
+	}
+	messages := make([]string, len(errors))
+	for index, err := range errors {
+		messages[index] = err.Error()
+	}
+	return &Error{
+		messageError: message + ": " + strings.Join(messages, "; "),
+		errorCode:    errorCode,
+		errors:       errors}
+}
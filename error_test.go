@@ -1,6 +1,10 @@
 package bike
 
-import "testing"
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
 
 func TestBikeError_GivenBikeErrorWhenErrorThenReturnErrorMessage(t *testing.T) {
 	// Given
@@ -29,3 +33,131 @@ func TestBikeError_GivenBikeErrorWhenErrorCodeThenReturnErrorCode(t *testing.T)
 		t.Errorf("ErrorCode must return expected value")
 	}
 }
+
+func TestJoinErrors_GivenEmptyErrors_WhenJoinErrors_ThenReturnNil(t *testing.T) {
+	// When
+	err := joinErrors(DestroyReturnError, "message", []*Error{})
+	// Then
+	if err != nil {
+		t.Errorf("joinErrors must return nil")
+	}
+}
+
+func TestJoinErrors_GivenErrors_WhenJoinErrors_ThenReturnMessageWithEveryError(t *testing.T) {
+	// Given
+	errors := []*Error{{messageError: "first"}, {messageError: "second"}}
+	// When
+	err := joinErrors(DestroyReturnError, "message", errors)
+	// Then
+	if err.Error() != "message: first; second" {
+		t.Errorf("joinErrors must return expected message, actual:%s", err.Error())
+	}
+	if len(err.Errors()) != 2 {
+		t.Errorf("Errors must return joined errors")
+	}
+}
+
+type DriverError struct {
+	Code string
+}
+
+func (_self *DriverError) Error() string {
+	return "driver error " + _self.Code
+}
+
+type FailingRepository struct{}
+
+func NewFailingRepository() (*FailingRepository, error) {
+	return nil, &DriverError{Code: "28P01"}
+}
+
+type FailingRepositoryConsumer struct{}
+
+func NewFailingRepositoryConsumer(repository *FailingRepository) *FailingRepositoryConsumer {
+	return &FailingRepositoryConsumer{}
+}
+
+func TestErrorCode_GivenErrorCode_WhenString_ThenReturnName(t *testing.T) {
+	// When
+	name := DependencyByTypeNotFound.String()
+	unknown := ErrorCode(200).String()
+	// Then
+	if name != "DependencyByTypeNotFound" || DependencyByTypeNotFound.Error() != name {
+		t.Errorf("String must return name of error code, actual:%s", name)
+	}
+	if unknown != "ErrorCode(200)" {
+		t.Errorf("String must return number of unknown error code, actual:%s", unknown)
+	}
+}
+
+func TestError_GivenConstructorErrorOfDependency_WhenStart_ThenErrorWrapsCause(t *testing.T) {
+	// Given
+	bike := NewBike()
+	bike.Add(Component{ID: "Repository", Constructor: NewFailingRepository, Lazy: true})
+	bike.Add(Component{ID: "Consumer", Constructor: NewFailingRepositoryConsumer})
+	// When
+	_, err := bike.Start()
+	// Then
+	var driverError *DriverError
+	if !errors.As(err, &driverError) || driverError.Code != "28P01" {
+		t.Error("errors.As must return error returned by constructor")
+	}
+	if !errors.Is(err, ConstructorReturnNotNilError) || errors.Is(err, DependencyByTypeNotFound) {
+		t.Error("errors.Is must match error code")
+	}
+	if err.ComponentID() != "Consumer" || err.DependencyType() != reflect.TypeOf(&FailingRepository{}) {
+		t.Errorf("Error must contain component ID and dependency type, actual:%s %v", err.ComponentID(), err.DependencyType())
+	}
+	if !reflect.DeepEqual(err.Path(), []string{"Consumer", "Repository"}) {
+		t.Errorf("Path must return components being resolved, actual:%v", err.Path())
+	}
+}
+
+func TestError_GivenDependencyNotFound_WhenResolve_ThenReturnDependencyType(t *testing.T) {
+	// Given
+	container, _ := NewBike().Start()
+	// When
+	_, err := Resolve[*FailingRepository](container)
+	// Then
+	if err.DependencyType() != reflect.TypeOf(&FailingRepository{}) || err.ComponentID() != "" || len(err.Path()) != 0 {
+		t.Error("Error must contain dependency type without component")
+	}
+}
+
+func TestError_GivenAggregatedErrors_WhenIsAndAs_ThenMatchAggregatedErrors(t *testing.T) {
+	// Given
+	cause := &DriverError{Code: "57P01"}
+	err := joinErrors(DestroyReturnError, "Error on Stop container", []*Error{
+		{messageError: "panic", errorCode: DestroyPanicked},
+		{messageError: "destroy", errorCode: DestroyReturnError, cause: cause},
+	})
+	// When
+	var driverError *DriverError
+	isPanicked := errors.Is(err, DestroyPanicked)
+	isTimeout := errors.Is(err, StartTimeout)
+	isDriverError := errors.As(err, &driverError)
+	// Then
+	if !isPanicked || isTimeout {
+		t.Error("errors.Is must match error codes of aggregated errors")
+	}
+	if !isDriverError || driverError != cause {
+		t.Error("errors.As must return cause of aggregated errors")
+	}
+	var contextErr interface{ Timeout() bool }
+	if errors.As(err, &contextErr) {
+		t.Error("errors.As must return false when no aggregated error matches")
+	}
+}
+
+func TestError_GivenPanicWithError_WhenStart_ThenUnwrapPanicValue(t *testing.T) {
+	// Given
+	cause := &DriverError{Code: "XX000"}
+	bike := NewBike()
+	bike.Add(Component{Constructor: func() *FailingRepository { panic(cause) }})
+	// When
+	_, err := bike.Start()
+	// Then
+	if !errors.Is(err, ConstructorPanicked) || !errors.Is(err, cause) {
+		t.Error("Error must wrap panic value")
+	}
+}
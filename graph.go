@@ -0,0 +1,152 @@
+package bike
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// dependencyEdge is a dependency of component. dependency is nil and err is not nil when no component
+// satisfies the dependency or the dependency is ambiguous
+type dependencyEdge struct {
+	component  *Component
+	dependency *Component
+	_type      reflect.Type
+	label      string
+	err        *Error
+}
+
+// dependenciesOf return components required by constructor, decorators and fields with bike tag of component.
+// Return an error when a dependency is ambiguous
+func (_self *Container) dependenciesOf(component *Component) ([]*Component, *Error) {
+	dependencies := make([]*Component, 0)
+	for _, edge := range _self.edgesOf(component) {
+		if edge.err != nil && edge.err.ErrorCode() == AmbiguousDependency {
+			return nil, edge.err
+		}
+		if edge.dependency != nil {
+			dependencies = append(dependencies, edge.dependency)
+		}
+	}
+	return dependencies, nil
+}
+
+// edgesOf return dependencies of constructor, decorators and fields with bike tag of component.
+// Optional dependencies not found and providers aren't returned, providers get instances after creation
+func (_self *Container) edgesOf(component *Component) []dependencyEdge {
+	edges := make([]dependencyEdge, 0)
+	addDependency := func(_type reflect.Type, qualifier string, optional bool, label string, required string) {
+		if isProviderType(_type) {
+			return
+		}
+		if isCollectionType(_type) {
+			for _, dependency := range _self.componentsOf(_type.Elem(), component.module) {
+				edges = append(edges, dependencyEdge{component: component, dependency: dependency, _type: _type, label: label})
+			}
+			return
+		}
+		if isOptionalType(_type) {
+			_type = reflect.New(_type).Interface().(optionalDependency).elemType()
+			optional = true
+		}
+		dependency, err := _self.componentByType(_type, qualifier, component.module)
+		if err != nil && (!optional || err.ErrorCode() == AmbiguousDependency) {
+			edges = append(edges, dependencyEdge{component: component, _type: _type, label: label, err: &Error{
+				messageError:   fmt.Sprintf("Error on Component %s. Error to get dependency: [%s] required by %s. %s", componentDescription(component), getTypeName(_type), required, err.Error()),
+				errorCode:      err.ErrorCode(),
+				cause:          err,
+				dependencyType: _type,
+				componentID:    component.ID}})
+		} else if err == nil {
+			edges = append(edges, dependencyEdge{component: component, dependency: dependency, _type: _type, label: label})
+		}
+	}
+
+	constructorType := component.constructorType()
+	for i := 0; i < constructorType.NumIn(); i++ {
+		required := fmt.Sprintf("Constructor:[%s]", getFuncName(component))
+		label := fmt.Sprintf("param %d: %s", i, constructorType.In(i).String())
+		addDependency(constructorType.In(i), component.Qualifiers[i], false, label, required)
+	}
+	for _, decorator := range _self.decoratorsOf(component) {
+		decoratorType := reflect.TypeOf(decorator)
+		for i := 1; i < decoratorType.NumIn(); i++ {
+			required := fmt.Sprintf("Decorator:[%s]", getDecoratorName(decorator))
+			label := fmt.Sprintf("decorator param %d: %s", i, decoratorType.In(i).String())
+			addDependency(decoratorType.In(i), "", false, label, required)
+		}
+	}
+	// Fields of instances registered by AddInstance aren't injected
+	if component.instance {
+		return edges
+	}
+	fields, _ := injectFieldsOf(component.componentType())
+	for _, field := range fields {
+		label := fmt.Sprintf("field %s: %s", field.name, field._type.String())
+		if len([]rune(field.id)) == 0 {
+			addDependency(field._type, field.qualifier, field.optional, label, fmt.Sprintf("field:[%s]", field.name))
+			continue
+		}
+		if dependency, ok := _self.componentOf(field.id, component.module); ok {
+			edges = append(edges, dependencyEdge{component: component, dependency: dependency, _type: field._type, label: label})
+		} else if !field.optional {
+			edges = append(edges, dependencyEdge{component: component, _type: field._type, label: label, err: &Error{
+				messageError:   fmt.Sprintf("Error on Component %s. Error to get dependency: [%s] required by field:[%s]. Component by id:%s not found", componentDescription(component), getTypeName(field._type), field.name, field.id),
+				errorCode:      DependencyByIDNotFound,
+				dependencyType: field._type,
+				componentID:    component.ID}})
+		}
+	}
+	return edges
+}
+
+// sortComponents return components sorted by dependencies, a component is always after its dependencies.
+// Return an error with the full path when a circular dependency is found
+func (_self *Container) sortComponents() ([]*Component, *Error) {
+	sortedComponents := make([]*Component, 0, len(_self.components))
+	visited := make(map[*Component]bool)
+	path := make([]*Component, 0)
+
+	var visit func(component *Component) *Error
+	visit = func(component *Component) *Error {
+		// Components of parent are already sorted by parent
+		if component.container != _self {
+			return nil
+		}
+		for index, pathComponent := range path {
+			if pathComponent == component {
+				return circularDependencyError(append(path[index:], component))
+			}
+		}
+		if visited[component] {
+			return nil
+		}
+		path = append(path, component)
+		for _, dependency := range component.dependencies {
+			if err := visit(dependency); err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+		visited[component] = true
+		sortedComponents = append(sortedComponents, component)
+		return nil
+	}
+
+	for _, component := range _self.components {
+		if err := visit(component); err != nil {
+			return nil, err
+		}
+	}
+	return sortedComponents, nil
+}
+
+func circularDependencyError(cycle []*Component) *Error {
+	names := make([]string, len(cycle))
+	for index, component := range cycle {
+		names[index] = fmt.Sprintf("%s(%s)", component.ID, getFuncName(component))
+	}
+	return &Error{
+		messageError: fmt.Sprintf("Circular dependency: %s", strings.Join(names, " -> ")),
+		errorCode:    CircularDependency}
+}
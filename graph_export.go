@@ -0,0 +1,168 @@
+package bike
+
+import (
+	"fmt"
+	"strings"
+)
+
+// dependencyGraph is the graph of components and their dependencies to export
+type dependencyGraph struct {
+	container  *Container
+	components []*Component
+	edges      []dependencyEdge
+}
+
+// GraphDOT return dependency graph of components in Graphviz DOT format
+func (_self *Container) GraphDOT() string {
+	return _self.dependencyGraph().dot()
+}
+
+// GraphMermaid return dependency graph of components in Mermaid format
+func (_self *Container) GraphMermaid() string {
+	return _self.dependencyGraph().mermaid()
+}
+
+// GraphDOT return dependency graph of components in Graphviz DOT format without starting bike.
+// Missing dependencies and circular dependencies are highlighted, invalid components aren't included
+func (_self *Bike) GraphDOT() string {
+	return _self.dependencyGraph().dot()
+}
+
+// GraphMermaid return dependency graph of components in Mermaid format without starting bike.
+// Missing dependencies and circular dependencies are highlighted, invalid components aren't included
+func (_self *Bike) GraphMermaid() string {
+	return _self.dependencyGraph().mermaid()
+}
+
+func (_self *Container) dependencyGraph() *dependencyGraph {
+	graph := &dependencyGraph{container: _self, components: _self.components}
+	for _, component := range _self.components {
+		graph.edges = append(graph.edges, _self.edgesOf(component)...)
+	}
+	// Components of parent are included after components of container
+	for _, edge := range graph.edges {
+		if edge.dependency != nil && graph.indexOf(edge.dependency) < 0 {
+			graph.components = append(graph.components, edge.dependency)
+		}
+	}
+	return graph
+}
+
+// dependencyGraph return graph of copies of valid components, bike and its components aren't modified
+func (_self *Bike) dependencyGraph() *dependencyGraph {
+	container, _ := _self.dryContainer()
+	return container.dependencyGraph()
+}
+
+func (_self *dependencyGraph) indexOf(component *Component) int {
+	for index, graphComponent := range _self.components {
+		if graphComponent == component {
+			return index
+		}
+	}
+	return -1
+}
+
+// inCycle return true when dependency of edge depends on component of edge, dependency must not be nil
+func (_self *dependencyGraph) inCycle(edge dependencyEdge) bool {
+	visited := make(map[*Component]bool)
+	var reaches func(from *Component) bool
+	reaches = func(from *Component) bool {
+		if from == edge.component {
+			return true
+		}
+		if visited[from] {
+			return false
+		}
+		visited[from] = true
+		for _, next := range _self.edges {
+			if next.component == from && next.dependency != nil && reaches(next.dependency) {
+				return true
+			}
+		}
+		return false
+	}
+	return reaches(edge.dependency)
+}
+
+// nodeLabel return lines of label of component: ID, type and scope
+func (_self *dependencyGraph) nodeLabel(component *Component) []string {
+	scope := component.Scope
+	scopeName := scope.String()
+	if len([]rune(scopeName)) == 0 {
+		scopeName = _self.container.customScopes[scope]
+	}
+	return []string{component.ID, component.componentType().String(), scopeName}
+}
+
+// missingLabel return lines of label of a dependency not satisfied by any component
+func missingLabel(edge dependencyEdge) []string {
+	if edge.err.ErrorCode() == AmbiguousDependency {
+		return []string{"ambiguous", edge._type.String()}
+	}
+	return []string{"missing", edge._type.String()}
+}
+
+func (_self *dependencyGraph) dot() string {
+	escape := strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+	label := func(lines []string) string {
+		for index, line := range lines {
+			lines[index] = escape.Replace(line)
+		}
+		return strings.Join(lines, `\n`)
+	}
+	var builder strings.Builder
+	builder.WriteString("digraph bike {\n\trankdir=LR;\n\tnode [shape=box];\n")
+	for index, component := range _self.components {
+		fmt.Fprintf(&builder, "\tn%d [label=\"%s\"];\n", index, label(_self.nodeLabel(component)))
+	}
+	for index, edge := range _self.edges {
+		from := _self.indexOf(edge.component)
+		edgeLabel := label([]string{edge.label})
+		if edge.dependency == nil {
+			fmt.Fprintf(&builder, "\tm%d [label=\"%s\", color=red, style=dashed];\n", index, label(missingLabel(edge)))
+			fmt.Fprintf(&builder, "\tn%d -> m%d [label=\"%s\", color=red, style=dashed];\n", from, index, edgeLabel)
+		} else if _self.inCycle(edge) {
+			fmt.Fprintf(&builder, "\tn%d -> n%d [label=\"%s\", color=red];\n", from, _self.indexOf(edge.dependency), edgeLabel)
+		} else {
+			fmt.Fprintf(&builder, "\tn%d -> n%d [label=\"%s\"];\n", from, _self.indexOf(edge.dependency), edgeLabel)
+		}
+	}
+	builder.WriteString("}\n")
+	return builder.String()
+}
+
+func (_self *dependencyGraph) mermaid() string {
+	escape := strings.NewReplacer(`"`, "#quot;")
+	label := func(lines []string) string {
+		for index, line := range lines {
+			lines[index] = escape.Replace(line)
+		}
+		return strings.Join(lines, "<br/>")
+	}
+	var builder strings.Builder
+	builder.WriteString("graph LR\n")
+	for index, component := range _self.components {
+		fmt.Fprintf(&builder, "\tn%d[\"%s\"]\n", index, label(_self.nodeLabel(component)))
+	}
+	highlighted := make([]string, 0)
+	for index, edge := range _self.edges {
+		from := _self.indexOf(edge.component)
+		edgeLabel := label([]string{edge.label})
+		if edge.dependency == nil {
+			fmt.Fprintf(&builder, "\tm%d[\"%s\"]:::missing\n", index, label(missingLabel(edge)))
+			fmt.Fprintf(&builder, "\tn%d -.->|\"%s\"| m%d\n", from, edgeLabel, index)
+			highlighted = append(highlighted, fmt.Sprint(index))
+			continue
+		}
+		fmt.Fprintf(&builder, "\tn%d -->|\"%s\"| n%d\n", from, edgeLabel, _self.indexOf(edge.dependency))
+		if _self.inCycle(edge) {
+			highlighted = append(highlighted, fmt.Sprint(index))
+		}
+	}
+	if len(highlighted) > 0 {
+		fmt.Fprintf(&builder, "\tlinkStyle %s stroke:red\n", strings.Join(highlighted, ","))
+	}
+	builder.WriteString("\tclassDef missing stroke:red,stroke-dasharray:5 5\n")
+	return builder.String()
+}
@@ -0,0 +1,155 @@
+package bike
+
+import (
+	"strings"
+	"testing"
+)
+
+type HelperPair struct{}
+
+func NewHelperPair(repository Repository, helper *RepositoryHelper) *HelperPair {
+	return &HelperPair{}
+}
+
+func newGraphContainer() *Container {
+	bike := NewBike()
+	bike.AddModule(newRepositoryModule())
+	bike.Add(Component{ID: "RepositoryService", Constructor: NewRepositoryService, Scope: Prototype})
+	container, _ := bike.Start()
+	return container
+}
+
+func TestGraphDOT_GivenContainer_WhenGraphDOT_ThenReturnGraph(t *testing.T) {
+	// Given
+	container := newGraphContainer()
+	// When
+	graph := container.GraphDOT()
+	// Then
+	expected := `digraph bike {
+	rankdir=LR;
+	node [shape=box];
+	n0 [label="RepositoryHelper\n*bike.RepositoryHelper\nSingleton"];
+	n1 [label="PostgresRepository\n*bike.PostgresRepository\nSingleton"];
+	n2 [label="RepositoryService\n*bike.RepositoryService\nPrototype"];
+	n1 -> n0 [label="field Helper: *bike.RepositoryHelper"];
+	n2 -> n1 [label="param 0: bike.Repository"];
+}
+`
+	if graph != expected {
+		t.Errorf("GraphDOT must return graph of components, actual:\n%s", graph)
+	}
+}
+
+func TestGraphMermaid_GivenContainer_WhenGraphMermaid_ThenReturnGraph(t *testing.T) {
+	// Given
+	container := newGraphContainer()
+	// When
+	graph := container.GraphMermaid()
+	// Then
+	expected := `graph LR
+	n0["RepositoryHelper<br/>*bike.RepositoryHelper<br/>Singleton"]
+	n1["PostgresRepository<br/>*bike.PostgresRepository<br/>Singleton"]
+	n2["RepositoryService<br/>*bike.RepositoryService<br/>Prototype"]
+	n1 -->|"field Helper: *bike.RepositoryHelper"| n0
+	n2 -->|"param 0: bike.Repository"| n1
+	classDef missing stroke:red,stroke-dasharray:5 5
+`
+	if graph != expected {
+		t.Errorf("GraphMermaid must return graph of components, actual:\n%s", graph)
+	}
+}
+
+func newInvalidGraphBike() *Bike {
+	bike := NewBike()
+	_ = bike.AddCustomScope(CustomScope, "custom")
+	bike.Add(Component{ID: "A", Constructor: NewCycleA})
+	bike.Add(Component{ID: "B", Constructor: NewCycleB})
+	bike.Add(Component{ID: "C", Constructor: NewCycleC})
+	bike.Add(Component{ID: `Service"1`, Constructor: NewRepositoryService, Scope: CustomScope})
+	bike.Add(Component{ID: "Counter1", Constructor: NewQueryCounter})
+	bike.Add(Component{ID: "Counter2", Constructor: NewQueryCounter})
+	bike.Add(Component{ID: "Invalid"})
+	bike.AddDecorator(countDecorator)
+	bike.AddDecorator("invalid")
+	return bike
+}
+
+func TestGraphDOT_GivenInvalidBike_WhenGraphDOT_ThenHighlightMissingAndCircularDependencies(t *testing.T) {
+	// Given
+	bike := newInvalidGraphBike()
+	// When
+	graph := bike.GraphDOT()
+	// Then
+	expectedLines := []string{
+		`n0 -> n1 [label="param 0: *bike.CycleB", color=red];`,
+		`n2 -> n0 [label="param 0: *bike.CycleA", color=red];`,
+		`n3 [label="Service\"1\n*bike.RepositoryService\ncustom"];`,
+		`m3 [label="missing\nbike.Repository", color=red, style=dashed];`,
+		`n3 -> m3 [label="param 0: bike.Repository", color=red, style=dashed];`,
+	}
+	for _, line := range expectedLines {
+		if !strings.Contains(graph, "\t"+line+"\n") {
+			t.Errorf("GraphDOT must contain %s, actual:\n%s", line, graph)
+		}
+	}
+	if strings.Contains(graph, "Invalid") || strings.Contains(graph, "decorator") {
+		t.Error("GraphDOT must not contain invalid components and decorators")
+	}
+}
+
+func TestGraphMermaid_GivenInvalidBike_WhenGraphMermaid_ThenHighlightMissingAndCircularDependencies(t *testing.T) {
+	// Given
+	bike := newInvalidGraphBike()
+	bike.Add(Component{ID: "Repository", Constructor: NewPostgresRepository, Interfaces: []any{(*Repository)(nil)}})
+	bike.Add(Component{ID: "Helper", Constructor: NewRepositoryHelper})
+	bike.Add(Component{ID: "Pair", Constructor: NewHelperPair})
+	bike.Add(Component{ID: "PairConsumer", Constructor: func(pair *HelperPair) *A { return &A{} }})
+	// When
+	graph := bike.GraphMermaid()
+	// Then
+	expectedLines := []string{
+		`n3["Service#quot;1<br/>*bike.RepositoryService<br/>custom"]`,
+		`n3 -->|"param 0: bike.Repository"| n6`,
+		`m4["ambiguous<br/>*bike.QueryCounter"]:::missing`,
+		`n6 -.->|"decorator param 1: *bike.QueryCounter"| m4`,
+		`n9 -->|"param 0: *bike.HelperPair"| n8`,
+		`linkStyle 0,1,2,4 stroke:red`,
+	}
+	for _, line := range expectedLines {
+		if !strings.Contains(graph, "\t"+line+"\n") {
+			t.Errorf("GraphMermaid must contain %s, actual:\n%s", line, graph)
+		}
+	}
+}
+
+func TestGraphDOT_GivenBike_WhenGraphDOT_ThenBikeCanBeStarted(t *testing.T) {
+	// Given
+	bike := NewBike()
+	bike.AddModule(newRepositoryModule())
+	_ = bike.GraphDOT()
+	// When
+	container, err := bike.Start()
+	// Then
+	if err != nil {
+		t.Errorf("Start must return nil error. Error:%s", err.Error())
+		return
+	}
+	if _, errRepository := Resolve[Repository](container); errRepository != nil {
+		t.Error("GraphDOT must not modify components of bike")
+	}
+}
+
+func TestGraphDOT_GivenChildContainer_WhenGraphDOT_ThenIncludeComponentsOfParent(t *testing.T) {
+	// Given
+	bike := NewBike()
+	bike.AddModule(newRepositoryModule())
+	parent, _ := bike.Start()
+	child, _ := parent.NewChild(Component{ID: "RepositoryService", Constructor: NewRepositoryService})
+	// When
+	graph := child.GraphDOT()
+	// Then
+	if !strings.Contains(graph, "\tn1 [label=\"PostgresRepository\\n*bike.PostgresRepository\\nSingleton\"];\n") ||
+		!strings.Contains(graph, "\tn0 -> n1 [label=\"param 0: bike.Repository\"];\n") {
+		t.Errorf("GraphDOT must include components of parent, actual:\n%s", graph)
+	}
+}
@@ -0,0 +1,130 @@
+package bike
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+const injectTagName = "bike"
+
+// injectField store data of a struct field with bike tag
+type injectField struct {
+	index     int
+	name      string
+	_type     reflect.Type
+	id        string
+	qualifier string
+	optional  bool
+}
+
+// injectFieldsOf return fields with bike tag of a pointer to struct type.
+// Supported tags: bike:"inject", bike:"id=<component id>", bike:"qualifier=<qualifier>", bike:"optional"
+// or a comma separated combination
+func injectFieldsOf(_type reflect.Type) ([]injectField, *Error) {
+	fields := make([]injectField, 0)
+	if _type.Kind() != reflect.Pointer || _type.Elem().Kind() != reflect.Struct {
+		return fields, nil
+	}
+	structType := _type.Elem()
+	for index := 0; index < structType.NumField(); index++ {
+		structField := structType.Field(index)
+		tag, ok := structField.Tag.Lookup(injectTagName)
+		if !ok {
+			continue
+		}
+		if !structField.IsExported() {
+			return nil, &Error{
+				messageError: fmt.Sprintf("Field:[%s] of %s with tag %s must be exported", structField.Name, getTypeName(_type), injectTagName),
+				errorCode:    InvalidInjectTag}
+		}
+		field := injectField{index: index, name: structField.Name, _type: structField.Type}
+		for _, option := range strings.Split(tag, ",") {
+			option = strings.TrimSpace(option)
+			switch {
+			case option == "inject":
+			case option == "optional":
+				field.optional = true
+			case strings.HasPrefix(option, "id=") && len(option) > len("id="):
+				field.id = strings.TrimPrefix(option, "id=")
+			case strings.HasPrefix(option, "qualifier=") && len(option) > len("qualifier="):
+				field.qualifier = strings.TrimPrefix(option, "qualifier=")
+			default:
+				return nil, &Error{
+					messageError: fmt.Sprintf("Invalid tag %s:\"%s\" on field:[%s] of %s", injectTagName, tag, structField.Name, getTypeName(_type)),
+					errorCode:    InvalidInjectTag}
+			}
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+// injectFields set fields with bike tag of instance, the lock of context must be held by caller
+func (_self *Container) injectFields(component *Component, instanceValue *reflect.Value, resolution *resolution) *Error {
+	value := *instanceValue
+	if value.Kind() == reflect.Interface {
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Pointer || value.IsNil() {
+		return nil
+	}
+	fields, err := injectFieldsOf(value.Type())
+	if err != nil {
+		return &Error{
+			messageError: fmt.Sprintf("Error on Component %s. %s", componentDescription(component), err.Error()),
+			errorCode:    err.ErrorCode(),
+			cause:        err,
+			componentID:  component.ID}
+	}
+	for _, field := range fields {
+		// Optional fields are skipped only when no component is registered, errors creating the component are returned
+		if field.optional && !_self.isRegistered(field, component.module) {
+			continue
+		}
+		var instance interface{}
+		var errInstance *Error
+		if len([]rune(field.id)) > 0 {
+			instance, errInstance = _self.resolveByID(field.id, component.module, resolution)
+		} else {
+			instance, errInstance = _self.resolveByType(field._type, field.qualifier, component.module, resolution)
+		}
+		if errInstance != nil {
+			return &Error{
+				messageError:   fmt.Sprintf("Error on Component %s. Error to get dependency: [%s] required by field:[%s]. %s", componentDescription(component), getTypeName(field._type), field.name, errInstance.Error()),
+				errorCode:      errInstance.ErrorCode(),
+				cause:          errInstance,
+				dependencyType: field._type,
+				componentID:    component.ID}
+		}
+		instanceFieldValue := reflect.ValueOf(instance)
+		if !instanceFieldValue.Type().AssignableTo(field._type) {
+			return &Error{
+				messageError:   fmt.Sprintf("Error on Component %s. Instance of type %s can't be assigned to field:[%s] of type %s", componentDescription(component), getTypeName(instanceFieldValue.Type()), field.name, getTypeName(field._type)),
+				errorCode:      InstanceTypeMismatch,
+				componentID:    component.ID,
+				dependencyType: field._type}
+		}
+		value.Elem().Field(field.index).Set(instanceFieldValue)
+	}
+	return nil
+}
+
+// isRegistered return true when a component is registered by id or type of field visible on module
+func (_self *Container) isRegistered(field injectField, module *Module) bool {
+	if len([]rune(field.id)) > 0 {
+		_, ok := _self.componentOf(field.id, module)
+		return ok
+	}
+	return _self.isRegisteredByType(field._type, field.qualifier, module)
+}
+
+// isRegisteredByType return true when a component is registered by type and qualifier visible on module.
+// Collections, optionals and providers are always registered
+func (_self *Container) isRegisteredByType(_type reflect.Type, qualifier string, module *Module) bool {
+	if isCollectionType(_type) || isOptionalType(_type) || isProviderType(_type) {
+		return true
+	}
+	_, err := _self.componentByType(_type, qualifier, module)
+	return err == nil || err.ErrorCode() != DependencyByTypeNotFound
+}
@@ -0,0 +1,185 @@
+package bike
+
+import (
+	"reflect"
+	"testing"
+)
+
+type FieldsComponent struct {
+	ByType        *A   `bike:"inject"`
+	ByID          *A   `bike:"id=IdA"`
+	Optional      *C   `bike:"optional"`
+	OptionalByID  *A   `bike:"id=IdMissing,optional"`
+	OptionalAll   []*C `bike:"optional"`
+	NotInjected   *A
+	PostConstruct bool
+}
+
+func (_self *FieldsComponent) Init() {
+	_self.PostConstruct = _self.ByType != nil
+}
+
+func NewFieldsComponent() *FieldsComponent {
+	return &FieldsComponent{}
+}
+
+func TestStart_GivenComponentWithInjectTags_WhenStart_ThenInjectFieldsBeforePostConstruct(t *testing.T) {
+	// Given
+	bike := NewBike()
+	bike.Add(Component{Constructor: NewFieldsComponent, PostConstruct: "Init"})
+	bike.Add(Component{ID: "IdA", Constructor: NewA})
+	// When
+	container, startErr := bike.Start()
+	// Then
+	if startErr != nil {
+		t.Errorf("Start must return nil error. Error:%s", startErr.Error())
+		return
+	}
+	instance := MustResolve[*FieldsComponent](container)
+	if instance.ByType == nil || instance.ByType != instance.ByID {
+		t.Errorf("Start must inject fields by type and id")
+	}
+	if instance.Optional != nil || instance.OptionalByID != nil || instance.NotInjected != nil {
+		t.Errorf("Start must not inject optional missing fields or fields without tag")
+	}
+	if instance.OptionalAll == nil || len(instance.OptionalAll) != 0 {
+		t.Errorf("Start must inject empty collection on optional collection field")
+	}
+	if !instance.PostConstruct {
+		t.Errorf("Start must inject fields before call PostConstruct")
+	}
+}
+
+func TestStart_GivenOptionalFieldWithMissingDependency_WhenStart_ThenReturnError(t *testing.T) {
+	// Given
+	bike := NewBike()
+	bike.Add(Component{Constructor: NewFieldsComponent})
+	bike.Add(Component{ID: "IdA", Constructor: NewA})
+	bike.Add(Component{Constructor: NewC, Scope: Prototype})
+	// When
+	_, startErr := bike.Start()
+	// Then
+	if startErr == nil || startErr.ErrorCode() != DependencyByTypeNotFound || startErr.DependencyType() != reflect.TypeOf(&C{}) {
+		t.Errorf("Start must return error of registered optional dependency, actual:%v", startErr)
+	}
+}
+
+func TestStart_GivenOptionalFieldByIDWithMissingDependency_WhenStart_ThenReturnError(t *testing.T) {
+	// Given
+	bike := NewBike()
+	bike.Add(Component{Constructor: NewFieldsComponent})
+	bike.Add(Component{ID: "IdA", Constructor: NewA, Primary: true})
+	bike.Add(Component{ID: "IdMissing", Constructor: func(b *B) *A { return &A{} }, Scope: Prototype})
+	// When
+	_, startErr := bike.Start()
+	// Then
+	if startErr == nil || startErr.ErrorCode() != DependencyByTypeNotFound || startErr.DependencyType() != reflect.TypeOf(&A{}) {
+		t.Errorf("Start must return error of registered optional dependency, actual:%v", startErr)
+	}
+}
+
+type ScopedFieldsComponent struct {
+	A *A `bike:"inject"`
+}
+
+func TestInstanceByTypeAndIDContext_GivenCustomScopeWithInjectTags_WhenInstanceByTypeAndIDContext_ThenInjectInstancesOfContext(t *testing.T) {
+	// Given
+	bike := NewBike()
+	_ = bike.AddCustomScope(CustomScope, "name")
+	bike.Add(Component{Constructor: func() *ScopedFieldsComponent { return &ScopedFieldsComponent{} }, Scope: CustomScope})
+	bike.Add(Component{Constructor: NewA, Scope: CustomScope})
+	container, _ := bike.Start()
+	// When
+	instance, _ := ResolveInContext[*ScopedFieldsComponent](container, CustomScope, "id")
+	a, _ := ResolveInContext[*A](container, CustomScope, "id")
+	// Then
+	if instance.A != a {
+		t.Errorf("InstanceByTypeAndIDContext must inject instance of same context")
+	}
+}
+
+type InvalidTagComponent struct {
+	A *A `bike:"invalid"`
+}
+
+func TestStart_GivenComponentWithInvalidTag_WhenStart_ThenReturnInvalidInjectTag(t *testing.T) {
+	// Given
+	bike := NewBike()
+	bike.Add(Component{Constructor: func() *InvalidTagComponent { return &InvalidTagComponent{} }})
+	bike.Add(Component{Constructor: NewA})
+	// When
+	_, startErr := bike.Start()
+	// Then
+	if startErr == nil || startErr.ErrorCode() != InvalidInjectTag {
+		t.Errorf("Start must return InvalidInjectTag error")
+	}
+}
+
+type UnexportedFieldComponent struct {
+	a *A `bike:"inject"`
+}
+
+func TestStart_GivenComponentWithUnexportedTaggedField_WhenStart_ThenReturnInvalidInjectTag(t *testing.T) {
+	// Given
+	bike := NewBike()
+	bike.Add(Component{Constructor: func() *UnexportedFieldComponent { return &UnexportedFieldComponent{} }})
+	// When
+	_, startErr := bike.Start()
+	// Then
+	if startErr == nil || startErr.ErrorCode() != InvalidInjectTag {
+		t.Errorf("Start must return InvalidInjectTag error")
+	}
+}
+
+func TestStart_GivenComponentReturnInterfaceWithInvalidTag_WhenStart_ThenReturnInvalidInjectTag(t *testing.T) {
+	// Given
+	bike := NewBike()
+	bike.Add(Component{Constructor: func() any { return &InvalidTagComponent{} }})
+	// When
+	_, startErr := bike.Start()
+	// Then
+	if startErr == nil || startErr.ErrorCode() != InvalidInjectTag {
+		t.Errorf("Start must return InvalidInjectTag error")
+	}
+}
+
+func TestStart_GivenComponentWithMissingInjectField_WhenStart_ThenReturnDependencyByTypeNotFound(t *testing.T) {
+	// Given
+	bike := NewBike()
+	bike.Add(Component{Constructor: func() *ScopedFieldsComponent { return &ScopedFieldsComponent{} }})
+	// When
+	_, startErr := bike.Start()
+	// Then
+	if startErr == nil || startErr.ErrorCode() != DependencyByTypeNotFound {
+		t.Errorf("Start must return DependencyByTypeNotFound error")
+	}
+}
+
+type WrongTypeFieldComponent struct {
+	A *A `bike:"id=IdB"`
+}
+
+func TestStart_GivenFieldWithIdOfOtherType_WhenStart_ThenReturnInstanceTypeMismatch(t *testing.T) {
+	// Given
+	bike := NewBike()
+	bike.Add(Component{Constructor: func() *WrongTypeFieldComponent { return &WrongTypeFieldComponent{} }})
+	bike.Add(Component{ID: "IdB", Constructor: NewComponent})
+	// When
+	_, startErr := bike.Start()
+	// Then
+	if startErr == nil || startErr.ErrorCode() != InstanceTypeMismatch {
+		t.Errorf("Start must return InstanceTypeMismatch error")
+	}
+}
+
+func TestStart_GivenConstructorReturnNilPointer_WhenStart_ThenSkipFieldInjection(t *testing.T) {
+	// Given
+	bike := NewBike()
+	bike.Add(Component{Constructor: func() *ScopedFieldsComponent { return nil }})
+	// When
+	_, startErr := bike.Start()
+	// Then
+	if startErr != nil {
+		t.Errorf("Start must return nil error")
+	}
+}
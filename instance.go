@@ -0,0 +1,49 @@
+package bike
+
+import "reflect"
+
+// InstanceOption set a field of a component created by an instance
+type InstanceOption func(component *Component)
+
+// WithID set ID of component
+func WithID(id string) InstanceOption {
+	return func(component *Component) {
+		component.ID = id
+	}
+}
+
+// WithInterfaces set interfaces implemented by component
+func WithInterfaces(interfaces ...any) InstanceOption {
+	return func(component *Component) {
+		component.Interfaces = interfaces
+	}
+}
+
+// WithDestroy set the method called on Container.Stop
+func WithDestroy(destroy string) InstanceOption {
+	return func(component *Component) {
+		component.Destroy = destroy
+	}
+}
+
+// InstanceComponent return a singleton component with an already created instance, instance must be a not nil pointer.
+// Fields with bike tag of instance aren't injected and PostConstruct isn't called, Destroy is called on Container.Stop
+func InstanceComponent(instance any, options ...InstanceOption) Component {
+	component := Component{Scope: Singleton, instance: true}
+	value := reflect.ValueOf(instance)
+	if value.Kind() == reflect.Pointer && !value.IsNil() {
+		constructorType := reflect.FuncOf(nil, []reflect.Type{value.Type()}, false)
+		component.Constructor = reflect.MakeFunc(constructorType, func(args []reflect.Value) []reflect.Value {
+			return []reflect.Value{value}
+		}).Interface()
+	}
+	for _, option := range options {
+		option(&component)
+	}
+	return component
+}
+
+// AddInstance add a singleton component with an already created instance, see InstanceComponent
+func (_self *Bike) AddInstance(instance any, options ...InstanceOption) {
+	_self.Add(InstanceComponent(instance, options...))
+}
@@ -0,0 +1,137 @@
+package bike
+
+import "testing"
+
+type Connection interface {
+	Close() error
+}
+
+type ConnectionStub struct {
+	closed bool
+}
+
+func (_self *ConnectionStub) Close() error {
+	_self.closed = true
+	return nil
+}
+
+type ConnectionConsumer struct {
+	Connection Connection `bike:"inject"`
+}
+
+func NewConnectionConsumer() *ConnectionConsumer {
+	return &ConnectionConsumer{}
+}
+
+func TestAddInstance_GivenPointer_WhenStart_ThenInjectInstance(t *testing.T) {
+	// Given
+	connection := &ConnectionStub{}
+	bike := NewBike()
+	bike.AddInstance(connection, WithID("Connection"), WithInterfaces((*Connection)(nil)), WithDestroy("Close"))
+	bike.Add(Component{Constructor: NewConnectionConsumer})
+	// When
+	container, err := bike.Start()
+	// Then
+	if err != nil {
+		t.Errorf("Start must return nil error. Error:%s", err.Error())
+		return
+	}
+	consumer, _ := Resolve[*ConnectionConsumer](container)
+	if consumer.Connection != connection {
+		t.Error("Start must inject instance by interface")
+	}
+	byID, _ := ResolveByID[*ConnectionStub](container, "Connection")
+	byType, _ := Resolve[*ConnectionStub](container)
+	if byID != connection || byType != connection {
+		t.Error("Resolve must return instance by id and by type")
+	}
+	if stopErr := container.Stop(); stopErr != nil || !connection.closed {
+		t.Error("Stop must call Destroy of instance")
+	}
+}
+
+type PrebuiltDependency struct {
+	Value string
+}
+
+type PrebuiltComponent struct {
+	Dependency  *PrebuiltDependency `bike:"inject"`
+	Missing     *ConnectionStub     `bike:"inject"`
+	initialized bool
+}
+
+func (_self *PrebuiltComponent) PostConstruct(container *Container) error {
+	_self.initialized = true
+	return nil
+}
+
+func TestAddInstance_GivenInstanceWithInjectTagsAndInitializer_WhenStart_ThenKeepInstanceAsIs(t *testing.T) {
+	// Given
+	dependency := &PrebuiltDependency{Value: "mine"}
+	prebuilt := &PrebuiltComponent{Dependency: dependency}
+	bike := NewBike()
+	bike.AddInstance(prebuilt)
+	bike.Add(Component{Constructor: func() *PrebuiltDependency { return &PrebuiltDependency{Value: "container"} }})
+	// When
+	validateErr := bike.Validate()
+	_, startErr := bike.Start()
+	// Then
+	if validateErr != nil || startErr != nil {
+		t.Errorf("Validate and Start must not report fields of instance, actual:%v %v", validateErr, startErr)
+		return
+	}
+	if prebuilt.Dependency != dependency || dependency.Value != "mine" || prebuilt.Missing != nil {
+		t.Errorf("Start must not inject fields of instance")
+	}
+	if prebuilt.initialized {
+		t.Errorf("Start must not call PostConstruct of instance")
+	}
+}
+
+func TestAddInstance_GivenInvalidInstance_WhenStart_ThenReturnInvalidInstance(t *testing.T) {
+	// Given
+	var nilConnection *ConnectionStub
+	testCases := map[string]any{"nil": nil, "nil pointer": nilConnection, "no pointer": ConnectionStub{}}
+	for name, instance := range testCases {
+		bike := NewBike()
+		bike.AddInstance(instance)
+		// When
+		_, err := bike.Start()
+		// Then
+		if err == nil || err.ErrorCode() != InvalidInstance {
+			t.Errorf("Start must return InvalidInstance error with %s instance", name)
+		}
+	}
+}
+
+func TestAddInstance_GivenInvalidDestroy_WhenStart_ThenReturnError(t *testing.T) {
+	// Given
+	bike := NewBike()
+	bike.AddInstance(&ConnectionStub{}, WithDestroy("Unknown"))
+	// When
+	_, err := bike.Start()
+	// Then
+	if err == nil || err.ErrorCode() != InvalidNumArgOnPostConstruct {
+		t.Error("Start must validate Destroy of instance")
+	}
+}
+
+func TestAddInstance_GivenDestroyPanicsAndDuplicateID_WhenStopAndStart_ThenErrorsDescribeInstance(t *testing.T) {
+	// Given
+	bike := NewBike()
+	bike.AddInstance(&PanicLifecycle{}, WithID("Instance"), WithDestroy("Close"))
+	container, _ := bike.Start()
+	bike.Add(Component{ID: "Instance", Constructor: NewPanicLifecycle})
+	// When
+	stopErr := container.Stop()
+	_, startErr := bike.Start()
+	// Then
+	expected := "Error on Component ID:[Instance]. Destroy panicked:[destroy panic], Instance:[*bike.PanicLifecycle]"
+	if stopErr == nil || stopErr.Errors()[0].Error() != expected {
+		t.Errorf("Destroy panic must describe instance, actual:%v", stopErr)
+	}
+	expected = "Error on Component ID:[Instance]. Duplicate ID, already registered by Instance:[*bike.PanicLifecycle]"
+	if startErr == nil || startErr.Error() != expected {
+		t.Errorf("Duplicate ID must describe instance, actual:%v", startErr)
+	}
+}
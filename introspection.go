@@ -0,0 +1,121 @@
+package bike
+
+import (
+	"reflect"
+	"sort"
+)
+
+// ComponentInfo is a read only description of a component registered on a container
+type ComponentInfo struct {
+	ID          string
+	Scope       Scope
+	Module      string
+	Type        reflect.Type
+	Interfaces  []reflect.Type
+	Constructor string
+	// PostConstruct, Destroy and PostStart are the lifecycle interface implemented by component, like bike.Initializer,
+	// or the method name set on component. PostConstruct is empty for instances registered by AddInstance
+	PostConstruct string
+	Destroy       string
+	PostStart     string
+	// Dependencies are IDs of components required by constructor, decorators and fields with bike tag
+	Dependencies []string
+	// Instantiated is true when singleton was created, any prototype was created
+	// or an instance exists on a live context of custom scope
+	Instantiated bool
+}
+
+// ContextInfo is a read only description of a live context of custom scope
+type ContextInfo struct {
+	Scope     Scope
+	ScopeName string
+	ID        string
+	Instances int
+}
+
+// Components return description of components registered on container in registration order.
+// Components of parent container aren't included
+func (_self *Container) Components() []ComponentInfo {
+	contexts := _self.liveContexts()
+	infos := make([]ComponentInfo, 0, len(_self.components))
+	for _, component := range _self.components {
+		componentType := component.componentType()
+		lifecycles := component.lifecycles()
+		info := ComponentInfo{
+			ID:           component.ID,
+			Scope:        component.Scope,
+			Type:         componentType,
+			Interfaces:   make([]reflect.Type, len(component.Interfaces)),
+			Destroy:      lifecycles[1].hook(componentType),
+			PostStart:    lifecycles[2].hook(componentType),
+			Dependencies: make([]string, len(component.dependencies)),
+		}
+		if component.module != nil {
+			info.Module = component.module.Name
+		}
+		if !component.instance {
+			info.Constructor = getFuncName(component)
+			info.PostConstruct = lifecycles[0].hook(componentType)
+		}
+		for index, inputInterface := range component.Interfaces {
+			info.Interfaces[index] = typeOf(inputInterface)
+		}
+		for index, dependency := range component.dependencies {
+			info.Dependencies[index] = dependency.ID
+		}
+		info.Instantiated = _self.instantiated(component, contexts)
+		infos = append(infos, info)
+	}
+	return infos
+}
+
+// Contexts return description of live contexts of custom scopes sorted by scope and id
+func (_self *Container) Contexts() []ContextInfo {
+	infos := make([]ContextInfo, 0)
+	for scope, contexts := range _self.liveContexts() {
+		for id, context := range contexts {
+			context.mutex.Lock()
+			instances := len(context.instances)
+			context.mutex.Unlock()
+			infos = append(infos, ContextInfo{Scope: scope, ScopeName: _self.customScopes[scope], ID: id, Instances: instances})
+		}
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		if infos[i].Scope != infos[j].Scope {
+			return infos[i].Scope < infos[j].Scope
+		}
+		return infos[i].ID < infos[j].ID
+	})
+	return infos
+}
+
+// liveContexts return a copy of contexts of custom scopes
+func (_self *Container) liveContexts() map[Scope]map[string]*scopeContext {
+	_self.contextsLock().RLock()
+	defer _self.contextsLock().RUnlock()
+	liveContexts := make(map[Scope]map[string]*scopeContext, len(_self.customScopeContexts))
+	for scope, contexts := range _self.customScopeContexts {
+		liveContexts[scope] = make(map[string]*scopeContext, len(contexts))
+		for id, context := range contexts {
+			liveContexts[scope][id] = context
+		}
+	}
+	return liveContexts
+}
+
+// instantiated return true when component was instantiated
+func (_self *Container) instantiated(component *Component, contexts map[Scope]map[string]*scopeContext) bool {
+	component.mutex.Lock()
+	created := component.instanceValue != nil || len(component.prototypeInstancesValue) > 0
+	component.mutex.Unlock()
+	for _, scopeContexts := range contexts {
+		for _, context := range scopeContexts {
+			context.mutex.Lock()
+			for _, instance := range context.created {
+				created = created || instance.component == component
+			}
+			context.mutex.Unlock()
+		}
+	}
+	return created
+}
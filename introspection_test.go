@@ -0,0 +1,125 @@
+package bike
+
+import (
+	"reflect"
+	"testing"
+)
+
+func newIntrospectionContainer() *Container {
+	bike := NewBike()
+	_ = bike.AddCustomScope(CustomScope, "custom")
+	bike.AddModule(newRepositoryModule())
+	bike.Add(Component{ID: "RepositoryService", Constructor: NewRepositoryService, Lazy: true})
+	bike.Add(Component{ID: "Prototype", Constructor: NewQueryCounter, Scope: Prototype})
+	bike.Add(Component{ID: "Scoped", Constructor: NewConnectionStub, Scope: CustomScope, Destroy: "Close"})
+	bike.AddInstance(&ConnectionStub{}, WithID("Instance"))
+	container, _ := bike.Start()
+	return container
+}
+
+func TestComponents_GivenContainer_WhenComponents_ThenReturnDescriptionInRegistrationOrder(t *testing.T) {
+	// Given
+	container := newIntrospectionContainer()
+	// When
+	components := container.Components()
+	// Then
+	ids := make([]string, len(components))
+	for index, component := range components {
+		ids[index] = component.ID
+	}
+	expectedIDs := []string{"RepositoryHelper", "PostgresRepository", "RepositoryService", "Prototype", "Scoped", "Instance"}
+	if !reflect.DeepEqual(ids, expectedIDs) {
+		t.Errorf("Components must return components in registration order, actual:%v", ids)
+		return
+	}
+	repository := components[1]
+	if repository.Module != "repository" || repository.Type != reflect.TypeOf(&PostgresRepository{}) ||
+		repository.Constructor != "github.com/kybsa/bike.NewPostgresRepository" ||
+		!reflect.DeepEqual(repository.Interfaces, []reflect.Type{reflect.TypeOf((*Repository)(nil)).Elem()}) ||
+		!reflect.DeepEqual(repository.Dependencies, []string{"RepositoryHelper"}) {
+		t.Errorf("Components must describe component, actual:%+v", repository)
+	}
+	if components[4].Scope != CustomScope || components[4].Destroy != "Close" || components[5].Constructor != "" {
+		t.Error("Components must return scope, lifecycle methods and empty constructor of instances")
+	}
+}
+
+func TestComponents_GivenComponentsImplementingLifecycleInterfaces_WhenComponents_ThenReturnInterfaces(t *testing.T) {
+	// Given
+	bike := NewBike()
+	bike.Add(Component{Constructor: NewLifecycleComponent, PostConstruct: "PostConstruct"})
+	bike.AddInstance(&LifecycleComponent{})
+	container, _ := bike.Start()
+	// When
+	components := container.Components()
+	// Then
+	component, instance := components[0], components[1]
+	if component.PostConstruct != "bike.Initializer" || component.Destroy != "bike.Disposer" || component.PostStart != "bike.Starter" {
+		t.Errorf("Components must return lifecycle interfaces, actual:%+v", component)
+	}
+	if instance.PostConstruct != "" || instance.Destroy != "bike.Disposer" || instance.PostStart != "bike.Starter" {
+		t.Errorf("Components must return lifecycle interfaces called on instances, actual:%+v", instance)
+	}
+}
+
+func TestComponents_GivenComponentsCreated_WhenComponents_ThenReturnInstantiated(t *testing.T) {
+	// Given
+	container := newIntrospectionContainer()
+	before := container.Components()
+	_, _ = Resolve[*RepositoryService](container)
+	_, _ = ResolveByID[*QueryCounter](container, "Prototype")
+	_, _ = ResolveByIDInContext[*ConnectionStub](container, "Scoped", CustomScope, "id")
+	// When
+	after := container.Components()
+	// Then
+	expectedBefore := []bool{true, true, false, false, false, true}
+	for index, component := range before {
+		if component.Instantiated != expectedBefore[index] {
+			t.Errorf("Instantiated of %s must be %t before resolve", component.ID, expectedBefore[index])
+		}
+	}
+	for _, component := range after {
+		if !component.Instantiated {
+			t.Errorf("Instantiated of %s must be true after resolve", component.ID)
+		}
+	}
+}
+
+func TestContexts_GivenLiveContexts_WhenContexts_ThenReturnContextsSorted(t *testing.T) {
+	// Given
+	container := newIntrospectionContainer()
+	_, _ = ResolveByIDInContext[*ConnectionStub](container, "Scoped", CustomScope, "b")
+	_, _ = ResolveByIDInContext[*ConnectionStub](container, "Scoped", CustomScope, "a")
+	_, _ = ResolveByIDInContext[*QueryCounter](container, "Prototype", CustomScope, "c")
+	// When
+	contexts := container.Contexts()
+	// Then
+	expected := []ContextInfo{
+		{Scope: CustomScope, ScopeName: "custom", ID: "a", Instances: 1},
+		{Scope: CustomScope, ScopeName: "custom", ID: "b", Instances: 1},
+		{Scope: CustomScope, ScopeName: "custom", ID: "c", Instances: 0},
+	}
+	if !reflect.DeepEqual(contexts, expected) {
+		t.Errorf("Contexts must return live contexts, actual:%+v", contexts)
+	}
+	_ = container.RemoveContext(CustomScope, "a")
+	if len(container.Contexts()) != 2 {
+		t.Error("Contexts must not return removed contexts")
+	}
+}
+
+func TestContexts_GivenSeveralCustomScopes_WhenContexts_ThenReturnContextsSortedByScope(t *testing.T) {
+	// Given
+	bike := NewBike()
+	_ = bike.AddCustomScope(CustomScope+1, "second")
+	_ = bike.AddCustomScope(CustomScope, "first")
+	container, _ := bike.Start()
+	_, _ = container.InstanceByIDAndIDContext("none", CustomScope+1, "a")
+	_, _ = container.InstanceByIDAndIDContext("none", CustomScope, "b")
+	// When
+	contexts := container.Contexts()
+	// Then
+	if len(contexts) != 2 || contexts[0].ScopeName != "first" || contexts[1].ScopeName != "second" {
+		t.Errorf("Contexts must be sorted by scope, actual:%+v", contexts)
+	}
+}
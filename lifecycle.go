@@ -0,0 +1,261 @@
+package bike
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// Initializer is implemented by components initialized after fields injection.
+// It's called instead of the method named by Component.PostConstruct. The container passed resolves instances
+// of the context being resolved, it must not be used from other goroutines until PostConstruct returns
+type Initializer interface {
+	PostConstruct(container *Container) error
+}
+
+// Disposer is implemented by components released on Container.Stop and Container.RemoveContext.
+// It's called instead of the method named by Component.Destroy
+type Disposer interface {
+	Destroy(ctx context.Context) error
+}
+
+// Starter is implemented by singleton components started after Bike.Start creates singletons.
+// It's called instead of the method named by Component.PostStart
+type Starter interface {
+	Start(ctx context.Context) error
+}
+
+var (
+	initializerType = reflect.TypeOf((*Initializer)(nil)).Elem()
+	disposerType    = reflect.TypeOf((*Disposer)(nil)).Elem()
+	starterType     = reflect.TypeOf((*Starter)(nil)).Elem()
+	contextType     = reflect.TypeOf((*context.Context)(nil)).Elem()
+)
+
+// lifecycle is a lifecycle interface and the method name set on the matching field of component
+type lifecycle struct {
+	_type  reflect.Type
+	method string
+	field  string
+}
+
+// lifecycles return PostConstruct, Destroy and PostStart lifecycles of component
+func (_self *Component) lifecycles() []lifecycle {
+	return []lifecycle{
+		{initializerType, _self.PostConstruct, "PostConstruct"},
+		{disposerType, _self.Destroy, "Destroy"},
+		{starterType, _self.PostStart, "PostStart"},
+	}
+}
+
+// hook return the lifecycle interface when componentType implements it, otherwise the method name
+func (_self lifecycle) hook(componentType reflect.Type) string {
+	if componentType.Implements(_self._type) {
+		return _self._type.String()
+	}
+	return _self.method
+}
+
+// Warnings return a warning by each valid component implementing a lifecycle interface and setting
+// the matching method name, the method name is ignored
+func (_self *Bike) Warnings() []string {
+	warnings := make([]string, 0)
+	for _, component := range _self.components {
+		if _self.validateComponent(component) != nil {
+			continue
+		}
+		componentType := component.componentType()
+		for _, lifecycle := range component.lifecycles() {
+			if componentType.Implements(lifecycle._type) && len([]rune(lifecycle.method)) > 0 {
+				warnings = append(warnings, fmt.Sprintf("Component %s implements %s, %s [%s] is ignored",
+					componentDescription(component), lifecycle._type.String(), lifecycle.field, lifecycle.method))
+			}
+		}
+	}
+	return warnings
+}
+
+// callPostConstruct call Initializer.PostConstruct or method named by Component.PostConstruct,
+// the container passed resolves instances of the context of resolution without waiting for its lock
+func (_self *Container) callPostConstruct(component *Component, instanceValue *reflect.Value, resolution *resolution) *Error {
+	container := _self.boundTo(resolution)
+	var err error
+	panicErr := callRecovering(component, PostConstructPanicked, "PostConstruct", func() {
+		if initializer, ok := instanceValue.Interface().(Initializer); ok {
+			err = initializer.PostConstruct(container)
+		} else if len([]rune(component.PostConstruct)) > 0 {
+			method, _ := component.componentType().MethodByName(component.PostConstruct)
+			in := []reflect.Value{*instanceValue}
+			if method.Type.NumIn() == 2 {
+				in = append(in, reflect.ValueOf(container))
+			}
+			err = errorOf(method.Func.Call(in))
+		}
+	})
+	if panicErr != nil {
+		return panicErr
+	}
+	if err != nil {
+		return &Error{
+			messageError: fmt.Sprintf("Error on Component %s. PostConstruct return an error:[%s]", componentDescription(component), err.Error()),
+			errorCode:    PostConstructReturnError,
+			cause:        err,
+			componentID:  component.ID}
+	}
+	return nil
+}
+
+// callDestroy call Disposer.Destroy with ctx or method named by Component.Destroy
+func callDestroy(ctx context.Context, component *Component, instanceValue *reflect.Value) *Error {
+	var err error
+	panicErr := callRecovering(component, DestroyPanicked, "Destroy", func() {
+		if disposer, ok := instanceValue.Interface().(Disposer); ok {
+			err = disposer.Destroy(ctx)
+		} else if len([]rune(component.Destroy)) > 0 {
+			method, _ := component.componentType().MethodByName(component.Destroy)
+			err = errorOf(method.Func.Call([]reflect.Value{*instanceValue}))
+		}
+	})
+	if panicErr != nil {
+		return panicErr
+	}
+	if err != nil {
+		return &Error{
+			messageError: fmt.Sprintf("Error on Component %s. Destroy return an error:[%s]", componentDescription(component), err.Error()),
+			errorCode:    DestroyReturnError,
+			cause:        err,
+			componentID:  component.ID}
+	}
+	return nil
+}
+
+// postStart return function calling Starter.Start or method named by Component.PostStart of singleton, ctx is passed
+// to method when it has a context.Context argument. Return nil when the singleton wasn't created or has neither
+func postStart(component *Component) func(ctx context.Context) error {
+	if component.Scope != Singleton || component.instanceValue == nil {
+		return nil
+	}
+	instanceValue := *component.instanceValue
+	if starter, ok := instanceValue.Interface().(Starter); ok {
+		return starter.Start
+	}
+	if len([]rune(component.PostStart)) == 0 {
+		return nil
+	}
+	method, _ := component.componentType().MethodByName(component.PostStart)
+	return func(ctx context.Context) error {
+		in := []reflect.Value{instanceValue}
+		if method.Type.NumIn() == 2 {
+			in = append(in, reflect.ValueOf(&ctx).Elem())
+		}
+		return errorOf(method.Func.Call(in))
+	}
+}
+
+// runPostStart call PostStart of singletons concurrently and wait until all finish or ctx is done. PostStart still running
+// when ctx is done are waited for until they return or shutdown timeout expires. Return components whose PostStart
+// didn't return and an error listing PostStart that failed, panicked or didn't finish before ctx is done in registration order
+func (_self *Container) runPostStart(ctx context.Context, timeout time.Duration) ([]*Component, *Error) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+	results := make(map[*Component]*Error)
+	started := make([]*Component, 0)
+	for _, component := range _self.components {
+		start := postStart(component)
+		if start == nil {
+			continue
+		}
+		started = append(started, component)
+		wg.Add(1)
+		go func(component *Component, start func(ctx context.Context) error) {
+			defer wg.Done()
+			err := callPostStart(ctx, component, start)
+			mutex.Lock()
+			defer mutex.Unlock()
+			results[component] = err
+		}(component, start)
+	}
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+	}
+
+	mutex.Lock()
+	errors := make([]*Error, 0)
+	for _, component := range started {
+		err, finished := results[component]
+		if !finished {
+			err = &Error{
+				messageError: fmt.Sprintf("Error on Component %s. PostStart didn't finish:[%s]", componentDescription(component), ctx.Err()),
+				errorCode:    StartTimeout,
+				cause:        ctx.Err(),
+				componentID:  component.ID}
+		}
+		if err != nil {
+			errors = append(errors, err)
+		}
+	}
+	mutex.Unlock()
+
+	// PostStart still running must not see the container stopped
+	var expired <-chan time.Time
+	if _self.shutdownTimeout > 0 {
+		timer := time.NewTimer(_self.shutdownTimeout)
+		defer timer.Stop()
+		expired = timer.C
+	}
+	select {
+	case <-done:
+	case <-expired:
+	}
+	mutex.Lock()
+	defer mutex.Unlock()
+	running := make([]*Component, 0)
+	for _, component := range started {
+		if _, finished := results[component]; !finished {
+			running = append(running, component)
+		}
+	}
+	return running, joinErrors(PostStartReturnError, "Error on PostStart", errors)
+}
+
+// callPostStart call start of component, return an error when start returns an error or panics
+func callPostStart(ctx context.Context, component *Component, start func(ctx context.Context) error) *Error {
+	var err error
+	panicErr := callRecovering(component, PostStartPanicked, "PostStart", func() {
+		err = start(ctx)
+	})
+	if panicErr != nil {
+		return panicErr
+	}
+	if err != nil {
+		return &Error{
+			messageError: fmt.Sprintf("Error on Component %s. PostStart return an error:[%s]", componentDescription(component), err.Error()),
+			errorCode:    PostStartReturnError,
+			cause:        err,
+			componentID:  component.ID}
+	}
+	return nil
+}
+
+// errorOf return first not nil error of values returned by a method
+func errorOf(returnValues []reflect.Value) error {
+	for _, value := range returnValues {
+		if value.Type().Implements(errorType) && !value.IsNil() {
+			return value.Interface().(error)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,407 @@
+package bike
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type LifecycleComponent struct {
+	container     *Container
+	initialized   int
+	started       int
+	destroyed     int
+	initializeErr error
+	destroyErr    error
+}
+
+func NewLifecycleComponent() *LifecycleComponent {
+	return &LifecycleComponent{}
+}
+
+func (_self *LifecycleComponent) PostConstruct(container *Container) error {
+	_self.container = container
+	_self.initialized++
+	return _self.initializeErr
+}
+
+func (_self *LifecycleComponent) Destroy(ctx context.Context) error {
+	_self.destroyed++
+	return _self.destroyErr
+}
+
+func (_self *LifecycleComponent) Start(ctx context.Context) error {
+	_self.started++
+	return nil
+}
+
+type NilErrorPostConstruct struct {
+	initialized bool
+}
+
+func (_self *NilErrorPostConstruct) Init() error {
+	_self.initialized = true
+	return nil
+}
+
+func TestLifecycle_GivenComponentImplementingInterfaces_WhenStartAndStop_ThenCallInterfaces(t *testing.T) {
+	// Given
+	bike := NewBike()
+	bike.Add(Component{Constructor: NewLifecycleComponent})
+	container, err := bike.Start()
+	if err != nil {
+		t.Errorf("Start must return nil error. Error:%s", err.Error())
+		return
+	}
+	component, _ := Resolve[*LifecycleComponent](container)
+	// When
+	stopErr := container.Stop()
+	// Then
+	if stopErr != nil {
+		t.Errorf("Stop must return nil error. Error:%s", stopErr.Error())
+	}
+	if component.container != container || component.initialized != 1 || component.started != 1 || component.destroyed != 1 {
+		t.Error("Lifecycle interfaces must be called once")
+	}
+}
+
+func TestLifecycle_GivenComponentImplementingInterfacesAndMethodNames_WhenStart_ThenCallInterfacesAndWarn(t *testing.T) {
+	// Given
+	bike := NewBike()
+	bike.Add(Component{ID: "Lifecycle", Constructor: NewLifecycleComponent, PostConstruct: "PostConstruct", Destroy: "Destroy", PostStart: "Start"})
+	// When
+	warnings := bike.Warnings()
+	validateErr := bike.Validate()
+	container, err := bike.Start()
+	// Then
+	expected := []string{
+		"Component ID:[Lifecycle] implements bike.Initializer, PostConstruct [PostConstruct] is ignored",
+		"Component ID:[Lifecycle] implements bike.Disposer, Destroy [Destroy] is ignored",
+		"Component ID:[Lifecycle] implements bike.Starter, PostStart [Start] is ignored",
+	}
+	if len(warnings) != len(expected) {
+		t.Errorf("Warnings must return a warning by lifecycle interface, actual:%v", warnings)
+		return
+	}
+	for index, warning := range warnings {
+		if warning != expected[index] {
+			t.Errorf("Warning must be %s, actual:%s", expected[index], warning)
+		}
+	}
+	if validateErr != nil {
+		t.Errorf("Validate must not report warnings as errors. Error:%s", validateErr.Error())
+	}
+	if err != nil {
+		t.Errorf("Start must return nil error. Error:%s", err.Error())
+		return
+	}
+	component, _ := Resolve[*LifecycleComponent](container)
+	_ = container.Stop()
+	if component.initialized != 1 || component.started != 1 || component.destroyed != 1 {
+		t.Error("Lifecycle interfaces must be called once")
+	}
+}
+
+func TestWarnings_GivenInvalidComponent_WhenWarnings_ThenReturnEmpty(t *testing.T) {
+	// Given
+	bike := NewBike()
+	bike.Add(Component{Constructor: NewLifecycleComponent, Scope: Prototype, Lazy: true, Destroy: "Destroy"})
+	// When
+	warnings := bike.Warnings()
+	// Then
+	if len(warnings) != 0 {
+		t.Error("Warnings must ignore invalid components")
+	}
+}
+
+func TestLifecycle_GivenScopedComponents_WhenRemoveContextAndStop_ThenCallDisposer(t *testing.T) {
+	// Given
+	bike := NewBike()
+	_ = bike.AddCustomScope(CustomScope, "custom")
+	bike.Add(Component{ID: "Prototype", Constructor: NewLifecycleComponent, Scope: Prototype})
+	bike.Add(Component{ID: "Scoped", Constructor: NewLifecycleComponent, Scope: CustomScope})
+	container, _ := bike.Start()
+	prototype, _ := ResolveByID[*LifecycleComponent](container, "Prototype")
+	scoped, _ := ResolveByIDInContext[*LifecycleComponent](container, "Scoped", CustomScope, "id")
+	// When
+	removeErr := container.RemoveContext(CustomScope, "id")
+	stopErr := container.Stop()
+	// Then
+	if removeErr != nil || stopErr != nil {
+		t.Error("RemoveContext and Stop must return nil error")
+	}
+	if prototype.initialized != 1 || prototype.started != 0 || prototype.destroyed != 1 || scoped.destroyed != 1 {
+		t.Error("Disposer must be called on prototype and scoped components, Starter only on singletons")
+	}
+}
+
+func TestLifecycle_GivenInitializerReturnError_WhenStart_ThenReturnPostConstructReturnError(t *testing.T) {
+	// Given
+	bike := NewBike()
+	bike.Add(Component{Constructor: func() *LifecycleComponent {
+		return &LifecycleComponent{initializeErr: errors.New("initialize error")}
+	}})
+	// When
+	_, err := bike.Start()
+	// Then
+	if err == nil || err.ErrorCode() != PostConstructReturnError {
+		t.Error("Start must return PostConstructReturnError error")
+	}
+}
+
+func TestLifecycle_GivenDisposerReturnError_WhenStop_ThenReturnDestroyReturnError(t *testing.T) {
+	// Given
+	bike := NewBike()
+	bike.Add(Component{Constructor: func() *LifecycleComponent {
+		return &LifecycleComponent{destroyErr: errors.New("destroy error")}
+	}})
+	container, _ := bike.Start()
+	// When
+	err := container.Stop()
+	// Then
+	if err == nil || err.ErrorCode() != DestroyReturnError {
+		t.Error("Stop must return DestroyReturnError error")
+	}
+}
+
+func TestPostConstruct_GivenMethodReturningNilError_WhenStart_ThenReturnNil(t *testing.T) {
+	// Given
+	bike := NewBike()
+	bike.Add(Component{Constructor: func() *NilErrorPostConstruct { return &NilErrorPostConstruct{} }, PostConstruct: "Init"})
+	// When
+	container, err := bike.Start()
+	// Then
+	if err != nil {
+		t.Errorf("Start must return nil error. Error:%s", err.Error())
+		return
+	}
+	component, _ := Resolve[*NilErrorPostConstruct](container)
+	if !component.initialized {
+		t.Error("PostConstruct must be called")
+	}
+}
+
+type contextKey string
+
+type StarterComponent struct {
+	start   func(ctx context.Context) error
+	stopped bool
+}
+
+func (_self *StarterComponent) Start(ctx context.Context) error {
+	return _self.start(ctx)
+}
+
+func (_self *StarterComponent) Destroy(ctx context.Context) error {
+	_self.stopped = true
+	return nil
+}
+
+func newStarterBike(starts ...func(ctx context.Context) error) (*Bike, []*StarterComponent) {
+	bike := NewBike()
+	components := make([]*StarterComponent, len(starts))
+	for index, start := range starts {
+		components[index] = &StarterComponent{start: start}
+		bike.AddInstance(components[index])
+	}
+	return bike, components
+}
+
+func TestStartContext_GivenStarter_WhenStartContext_ThenPassContext(t *testing.T) {
+	// Given
+	var value any
+	bike, _ := newStarterBike(func(ctx context.Context) error {
+		value = ctx.Value(contextKey("key"))
+		return nil
+	})
+	// When
+	_, err := bike.StartContext(context.WithValue(context.Background(), contextKey("key"), "value"))
+	// Then
+	if err != nil || value != "value" {
+		t.Error("StartContext must pass context to Starter")
+	}
+}
+
+func TestStartContext_GivenPostStartFailing_WhenStartContext_ThenReturnEveryErrorAndStop(t *testing.T) {
+	// Given
+	bike, components := newStarterBike(
+		func(ctx context.Context) error { return nil },
+		func(ctx context.Context) error { return errors.New("warm-up error") },
+		func(ctx context.Context) error { panic("warm-up panic") },
+	)
+	// When
+	container, err := bike.StartContext(context.Background())
+	// Then
+	if container != nil || err == nil || err.ErrorCode() != PostStartReturnError {
+		t.Error("StartContext must return nil container and PostStartReturnError error")
+		return
+	}
+	if len(err.Errors()) != 2 || err.Errors()[0].ErrorCode() != PostStartReturnError || err.Errors()[1].ErrorCode() != PostStartPanicked {
+		t.Errorf("StartContext must return every error in registration order. Error:%s", err.Error())
+	}
+	for _, component := range components {
+		if !component.stopped {
+			t.Error("StartContext must stop container when a PostStart fails")
+		}
+	}
+}
+
+func TestStartContext_GivenPostStartMethodReturnError_WhenStart_ThenReturnPostStartReturnError(t *testing.T) {
+	// Given
+	bike := NewBike()
+	bike.Add(Component{Constructor: NewComponentWithPostStartError, PostStart: "PostStartError"})
+	// When
+	_, err := bike.Start()
+	// Then
+	if err == nil || err.Errors()[0].ErrorCode() != PostStartReturnError {
+		t.Error("Start must return PostStartReturnError error")
+	}
+}
+
+func TestStartContext_GivenStartTimeout_WhenPostStartDoesNotFinish_ThenReturnStartTimeout(t *testing.T) {
+	// Given
+	release := make(chan struct{})
+	defer close(release)
+	recorder := &DestroyRecorder{}
+	bike, components := newStarterBike(func(ctx context.Context) error { return nil })
+	blocked := &StarterComponent{start: func(ctx context.Context) error {
+		<-release
+		return nil
+	}}
+	bike.Add(Component{Constructor: func(database *DatabaseComponent, service *ServiceComponent) *StarterComponent { return blocked }})
+	bike.Add(Component{Constructor: func(database *DatabaseComponent) *ServiceComponent { return &ServiceComponent{recorder: recorder} }, Destroy: "Close"})
+	bike.Add(Component{Constructor: func() *DatabaseComponent { return &DatabaseComponent{recorder: recorder} }, Destroy: "Close"})
+	bike.SetStartTimeout(50 * time.Millisecond)
+	bike.SetShutdownTimeout(10 * time.Millisecond)
+	// When
+	_, err := bike.StartContext(context.Background())
+	// Then
+	if err == nil || len(err.Errors()) != 1 || err.Errors()[0].ErrorCode() != StartTimeout {
+		t.Error("StartContext must return StartTimeout error")
+	}
+	if !components[0].stopped || blocked.stopped || len(recorder.destroyed) != 0 {
+		t.Error("StartContext must not destroy components whose PostStart is running nor their dependencies")
+	}
+}
+
+type DestroyHook struct {
+	destroy func()
+}
+
+func (_self *DestroyHook) Destroy(ctx context.Context) error {
+	_self.destroy()
+	return nil
+}
+
+func TestStartContext_GivenPostStartReturningAfterTimeout_WhenStartContext_ThenDestroyAfterPostStartReturns(t *testing.T) {
+	// Given
+	var returned atomic.Bool
+	var destroyedAfterReturn atomic.Bool
+	bike := NewBike()
+	bike.AddInstance(&StarterComponent{start: func(ctx context.Context) error {
+		<-ctx.Done()
+		time.Sleep(20 * time.Millisecond)
+		returned.Store(true)
+		return ctx.Err()
+	}})
+	bike.SetStartTimeout(10 * time.Millisecond)
+	bike.AddInstance(&DestroyHook{destroy: func() { destroyedAfterReturn.Store(returned.Load()) }})
+	// When
+	_, err := bike.StartContext(context.Background())
+	// Then
+	if err == nil || len(err.Errors()) != 1 || err.Errors()[0].ErrorCode() != StartTimeout {
+		t.Errorf("StartContext must return StartTimeout error, actual:%v", err)
+	}
+	if !destroyedAfterReturn.Load() {
+		t.Error("StartContext must wait for PostStart to return before stopping the container")
+	}
+}
+
+func TestStartContext_GivenStopFailing_WhenPostStartFails_ThenReturnStopError(t *testing.T) {
+	// Given
+	bike, _ := newStarterBike(func(ctx context.Context) error { return errors.New("warm-up error") })
+	bike.Add(Component{Constructor: func() *LifecycleComponent {
+		return &LifecycleComponent{destroyErr: errors.New("destroy error")}
+	}})
+	// When
+	_, err := bike.StartContext(context.Background())
+	// Then
+	if err == nil || len(err.Errors()) != 2 || err.Errors()[1].ErrorCode() != DestroyReturnError {
+		t.Error("StartContext must return error of Stop")
+	}
+}
+
+func TestStartContext_GivenCanceledContextWithoutPostStart_WhenStartContext_ThenReturnContainer(t *testing.T) {
+	// Given
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	bike := NewBike()
+	bike.Add(Component{Constructor: NewComponent})
+	// When
+	container, err := bike.StartContext(ctx)
+	// Then
+	if container == nil || err != nil {
+		t.Error("StartContext must return container when there are no PostStart")
+	}
+}
+
+type PostStartErrorComponent struct{}
+
+func NewComponentWithPostStartError() *PostStartErrorComponent {
+	return &PostStartErrorComponent{}
+}
+
+func (_self *PostStartErrorComponent) PostStartError() error {
+	return errors.New("post start error")
+}
+
+type WarmComponent struct {
+	ctx context.Context
+}
+
+func (_self *WarmComponent) Warm(ctx context.Context) error {
+	_self.ctx = ctx
+	return nil
+}
+
+func (_self *WarmComponent) WarmWithName(name string) error {
+	return nil
+}
+
+func (_self *WarmComponent) WarmWithContextAndName(ctx context.Context, name string) error {
+	return nil
+}
+
+func TestStartContext_GivenPostStartWithContextArgument_WhenStartContext_ThenPassContext(t *testing.T) {
+	// Given
+	warm := &WarmComponent{}
+	bike := NewBike()
+	bike.Add(Component{Constructor: func() *WarmComponent { return warm }, PostStart: "Warm"})
+	ctx := context.WithValue(context.Background(), contextKey("key"), "value")
+	// When
+	_, err := bike.StartContext(ctx)
+	// Then
+	if err != nil {
+		t.Errorf("StartContext must return nil error. Error:%s", err.Error())
+		return
+	}
+	if warm.ctx == nil || warm.ctx.Value(contextKey("key")) != "value" {
+		t.Error("StartContext must pass context to PostStart")
+	}
+}
+
+func TestStart_GivenPostStartWithInvalidArguments_WhenStart_ThenReturnError(t *testing.T) {
+	for _, postStart := range []string{"WarmWithName", "WarmWithContextAndName"} {
+		// Given
+		bike := NewBike()
+		bike.Add(Component{Constructor: func() *WarmComponent { return &WarmComponent{} }, PostStart: postStart})
+		// When
+		_, err := bike.Start()
+		// Then
+		if err == nil || err.ErrorCode() != InvalidNumArgOnPostConstruct {
+			t.Errorf("Start must return InvalidNumArgOnPostConstruct error for PostStart %s", postStart)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package bike
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Module groups components. Components of a module can inject components of the same module, exported types
+// of imported modules and components added to Bike out of modules. Out of the module only exported types are visible
+type Module struct {
+	Name       string
+	Components []Component
+	Imports    []*Module
+	Exports    []any
+}
+
+// imports return true when module is imported by this module
+func (_self *Module) imports(module *Module) bool {
+	for _, imported := range _self.Imports {
+		if imported == module {
+			return true
+		}
+	}
+	return false
+}
+
+// exports return true when _type is exported by this module
+func (_self *Module) exports(_type reflect.Type) bool {
+	for _, export := range _self.Exports {
+		if typeOf(export) == _type {
+			return true
+		}
+	}
+	return false
+}
+
+// validateExports check every exported type is provided by a component of module
+func (_self *Module) validateExports(container *Container) *Error {
+	for _, export := range _self.Exports {
+		exportType := typeOf(export)
+		provided := false
+		for _, component := range container.componentsByType[exportType] {
+			provided = provided || component.module == _self
+		}
+		if !provided {
+			return &Error{
+				messageError: fmt.Sprintf("Error on Module:[%s]. Exported type %s isn't provided by any component of module", _self.Name, getTypeName(exportType)),
+				errorCode:    InvalidModuleExport}
+		}
+	}
+	return nil
+}
+
+// visibleTo return true when the component registered by _type can be injected on components of module.
+// _type is nil on lookups by ID, in that case any type of component must be exported
+func (_self *Component) visibleTo(module *Module, _type reflect.Type) bool {
+	if _self.module == nil || _self.module == module {
+		return true
+	}
+	if module != nil && !module.imports(_self.module) {
+		return false
+	}
+	if _type != nil {
+		return _self.module.exports(_type)
+	}
+	for _, componentType := range _self.types() {
+		if _self.module.exports(componentType) {
+			return true
+		}
+	}
+	return false
+}
+
+// componentDescription return ID of component and the name of its module
+func componentDescription(component *Component) string {
+	if component.module == nil {
+		return fmt.Sprintf("ID:[%s]", component.ID)
+	}
+	return fmt.Sprintf("ID:[%s] Module:[%s]", component.ID, component.module.Name)
+}
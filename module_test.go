@@ -0,0 +1,199 @@
+package bike
+
+import (
+	"strings"
+	"testing"
+)
+
+type Repository interface {
+	Find() string
+}
+
+type RepositoryHelper struct{}
+
+func NewRepositoryHelper() *RepositoryHelper {
+	return &RepositoryHelper{}
+}
+
+type PostgresRepository struct {
+	Helper *RepositoryHelper `bike:"inject"`
+}
+
+func NewPostgresRepository() *PostgresRepository {
+	return &PostgresRepository{}
+}
+
+func (_self *PostgresRepository) Find() string {
+	return "postgres"
+}
+
+type RepositoryService struct {
+	repository Repository
+}
+
+func NewRepositoryService(repository Repository) *RepositoryService {
+	return &RepositoryService{repository: repository}
+}
+
+func newRepositoryModule() *Module {
+	return &Module{
+		Name: "repository",
+		Components: []Component{
+			{ID: "RepositoryHelper", Constructor: NewRepositoryHelper},
+			{ID: "PostgresRepository", Constructor: NewPostgresRepository, Interfaces: []any{(*Repository)(nil)}},
+		},
+		Exports: []any{(*Repository)(nil)},
+	}
+}
+
+func TestModule_GivenExportedType_WhenResolveOutOfModule_ThenReturnInstance(t *testing.T) {
+	// Given
+	bike := NewBike()
+	bike.AddModule(newRepositoryModule())
+	container, _ := bike.Start()
+	// When
+	repository, err := Resolve[Repository](container)
+	// Then
+	if err != nil {
+		t.Errorf("Resolve must return nil error. Error:%s", err.Error())
+		return
+	}
+	if repository.Find() != "postgres" || repository.(*PostgresRepository).Helper == nil {
+		t.Error("Resolve must return component of module with dependencies of module")
+	}
+}
+
+func TestModule_GivenNotExportedType_WhenResolveOutOfModule_ThenReturnError(t *testing.T) {
+	// Given
+	bike := NewBike()
+	bike.AddModule(newRepositoryModule())
+	container, _ := bike.Start()
+	// When
+	_, errByType := Resolve[*RepositoryHelper](container)
+	_, errByID := container.InstanceByID("RepositoryHelper")
+	// Then
+	if errByType == nil || errByType.ErrorCode() != DependencyByTypeNotFound {
+		t.Error("Resolve must return DependencyByTypeNotFound error")
+	}
+	if errByID == nil || errByID.ErrorCode() != DependencyByIDNotFound {
+		t.Error("InstanceByID must return DependencyByIDNotFound error")
+	}
+}
+
+func TestModule_GivenComponentWithExportedType_WhenInstanceByID_ThenReturnInstance(t *testing.T) {
+	// Given
+	bike := NewBike()
+	bike.AddModule(newRepositoryModule())
+	container, _ := bike.Start()
+	// When
+	instance, err := container.InstanceByID("PostgresRepository")
+	// Then
+	if err != nil || instance == nil {
+		t.Error("InstanceByID must return component with exported type")
+	}
+}
+
+func TestModule_GivenModuleImportingModule_WhenStart_ThenInjectExportedType(t *testing.T) {
+	// Given
+	repositoryModule := newRepositoryModule()
+	bike := NewBike()
+	bike.AddModule(&Module{
+		Name:       "service",
+		Components: []Component{{Constructor: NewRepositoryService}},
+		Imports:    []*Module{repositoryModule},
+		Exports:    []any{(*RepositoryService)(nil)},
+	})
+	// When
+	container, err := bike.Start()
+	// Then
+	if err != nil {
+		t.Errorf("Start must return nil error. Error:%s", err.Error())
+		return
+	}
+	service, _ := Resolve[*RepositoryService](container)
+	if service.repository.Find() != "postgres" {
+		t.Error("Start must inject exported type of imported module")
+	}
+}
+
+func TestModule_GivenModuleNotImportingModule_WhenStart_ThenReturnErrorWithModuleName(t *testing.T) {
+	// Given
+	bike := NewBike()
+	bike.AddModule(newRepositoryModule())
+	bike.AddModule(&Module{
+		Name:       "service",
+		Components: []Component{{ID: "RepositoryService", Constructor: NewRepositoryService}},
+	})
+	// When
+	_, err := bike.Start()
+	// Then
+	if err == nil || err.ErrorCode() != DependencyByTypeNotFound {
+		t.Error("Start must return DependencyByTypeNotFound error")
+		return
+	}
+	if !strings.Contains(err.Error(), "ID:[RepositoryService] Module:[service]") {
+		t.Errorf("Error must contain module name. Error:%s", err.Error())
+	}
+}
+
+func TestModule_GivenModuleImportingNotExportedType_WhenStart_ThenReturnError(t *testing.T) {
+	// Given
+	type HelperConsumer struct {
+		Helper *RepositoryHelper `bike:"inject"`
+	}
+	bike := NewBike()
+	bike.AddModule(&Module{
+		Name:       "consumer",
+		Components: []Component{{Constructor: func() *HelperConsumer { return &HelperConsumer{} }}},
+		Imports:    []*Module{newRepositoryModule()},
+	})
+	// When
+	_, err := bike.Start()
+	// Then
+	if err == nil || err.ErrorCode() != DependencyByTypeNotFound {
+		t.Error("Start must return DependencyByTypeNotFound error")
+	}
+}
+
+func TestModule_GivenExportNotProvided_WhenStart_ThenReturnInvalidModuleExport(t *testing.T) {
+	// Given
+	bike := NewBike()
+	bike.AddModule(&Module{
+		Name:       "repository",
+		Components: []Component{{Constructor: NewRepositoryHelper}},
+		Exports:    []any{(*Repository)(nil)},
+	})
+	// When
+	_, err := bike.Start()
+	// Then
+	if err == nil || err.ErrorCode() != InvalidModuleExport {
+		t.Error("Start must return InvalidModuleExport error")
+		return
+	}
+	if !strings.Contains(err.Error(), "Module:[repository]") {
+		t.Errorf("Error must contain module name. Error:%s", err.Error())
+	}
+}
+
+func TestAddModule_GivenModuleAddedAndImported_WhenStart_ThenAddComponentsOnce(t *testing.T) {
+	// Given
+	repositoryModule := newRepositoryModule()
+	bike := NewBike()
+	bike.AddModule(repositoryModule)
+	bike.AddModule(&Module{
+		Name:       "service",
+		Components: []Component{{Constructor: NewRepositoryService}},
+		Imports:    []*Module{repositoryModule},
+	})
+	bike.AddModule(repositoryModule)
+	// When
+	container, err := bike.Start()
+	// Then
+	if err != nil {
+		t.Errorf("Start must return nil error. Error:%s", err.Error())
+		return
+	}
+	if len(container.components) != 3 {
+		t.Errorf("Components of module must be added once, components:%d", len(container.components))
+	}
+}
@@ -0,0 +1,70 @@
+package bike
+
+import "reflect"
+
+// Optional is a dependency that is injected only when a component is registered by type T
+type Optional[T any] struct {
+	value   T
+	present bool
+}
+
+// NewOptional return an Optional with value
+func NewOptional[T any](value T) Optional[T] {
+	return Optional[T]{value: value, present: true}
+}
+
+// Get return the value and true when the dependency was injected
+func (_self Optional[T]) Get() (T, bool) {
+	return _self.value, _self.present
+}
+
+// IsPresent return true when the dependency was injected
+func (_self Optional[T]) IsPresent() bool {
+	return _self.present
+}
+
+// OrElse return the value or defaultValue when the dependency wasn't injected
+func (_self Optional[T]) OrElse(defaultValue T) T {
+	if _self.present {
+		return _self.value
+	}
+	return defaultValue
+}
+
+func (_self *Optional[T]) elemType() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+func (_self *Optional[T]) set(value interface{}) {
+	_self.value = value.(T)
+	_self.present = true
+}
+
+// optionalDependency is implemented by pointer to Optional to set value by reflection
+type optionalDependency interface {
+	elemType() reflect.Type
+	set(value interface{})
+}
+
+var optionalDependencyType = reflect.TypeOf((*optionalDependency)(nil)).Elem()
+
+// isOptionalType return true when _type is an Optional
+func isOptionalType(_type reflect.Type) bool {
+	return _type.Kind() == reflect.Struct && reflect.PointerTo(_type).Implements(optionalDependencyType)
+}
+
+// resolveOptional return an Optional with instance of elem type or an empty Optional when no component is registered.
+// Errors creating a registered component are returned. The lock of context must be held by caller
+func (_self *Container) resolveOptional(_type reflect.Type, qualifier string, module *Module, resolution *resolution) (interface{}, *Error) {
+	optional := reflect.New(_type)
+	dependency := optional.Interface().(optionalDependency)
+	if !_self.isRegisteredByType(dependency.elemType(), qualifier, module) {
+		return optional.Elem().Interface(), nil
+	}
+	instance, err := _self.resolveByType(dependency.elemType(), qualifier, module, resolution)
+	if err != nil {
+		return nil, err
+	}
+	dependency.set(instance)
+	return optional.Elem().Interface(), nil
+}
@@ -0,0 +1,160 @@
+package bike
+
+import (
+	"errors"
+	"testing"
+)
+
+type Metrics interface {
+	Count(name string)
+}
+
+type NoopMetrics struct{}
+
+func (_self *NoopMetrics) Count(name string) {}
+
+type PrometheusMetrics struct{}
+
+func (_self *PrometheusMetrics) Count(name string) {}
+
+type Handler struct {
+	Metrics      Optional[Metrics]
+	FieldMetrics Optional[Metrics] `bike:"inject"`
+}
+
+func NewHandler(metrics Optional[Metrics]) *Handler {
+	return &Handler{Metrics: metrics}
+}
+
+func TestOptional_GivenNewOptional_WhenGet_ThenReturnValueAndTrue(t *testing.T) {
+	// Given
+	metrics := &NoopMetrics{}
+	optional := NewOptional[Metrics](metrics)
+	// When
+	value, ok := optional.Get()
+	// Then
+	if value != metrics || !ok || !optional.IsPresent() {
+		t.Errorf("Get must return value and true")
+	}
+	if optional.OrElse(&PrometheusMetrics{}) != metrics {
+		t.Errorf("OrElse must return value")
+	}
+}
+
+func TestOptional_GivenEmptyOptional_WhenOrElse_ThenReturnDefaultValue(t *testing.T) {
+	// Given
+	optional := Optional[Metrics]{}
+	defaultValue := &NoopMetrics{}
+	// When
+	value := optional.OrElse(defaultValue)
+	// Then
+	if value != defaultValue || optional.IsPresent() {
+		t.Errorf("OrElse must return default value")
+	}
+}
+
+func TestStart_GivenOptionalDependencyNotRegistered_WhenStart_ThenInjectEmptyOptional(t *testing.T) {
+	// Given
+	bike := NewBike()
+	bike.Add(Component{Constructor: NewHandler})
+	// When
+	container, startErr := bike.Start()
+	// Then
+	if startErr != nil {
+		t.Errorf("Start must return nil error. Error:%s", startErr.Error())
+		return
+	}
+	handler := MustResolve[*Handler](container)
+	if handler.Metrics.IsPresent() || handler.FieldMetrics.IsPresent() {
+		t.Errorf("Start must inject empty Optional")
+	}
+}
+
+func TestStart_GivenOptionalDependencyRegistered_WhenStart_ThenInjectOptionalWithValue(t *testing.T) {
+	// Given
+	bike := NewBike()
+	bike.Add(Component{Constructor: NewHandler})
+	bike.Add(Component{Constructor: func() *PrometheusMetrics { return &PrometheusMetrics{} }, Interfaces: []any{(*Metrics)(nil)}})
+	// When
+	container, startErr := bike.Start()
+	// Then
+	if startErr != nil {
+		t.Errorf("Start must return nil error. Error:%s", startErr.Error())
+		return
+	}
+	handler := MustResolve[*Handler](container)
+	if !handler.Metrics.IsPresent() || !handler.FieldMetrics.IsPresent() {
+		t.Errorf("Start must inject Optional with value")
+	}
+}
+
+func TestStart_GivenOptionalDependencyReturnError_WhenStart_ThenReturnError(t *testing.T) {
+	// Given
+	bike := NewBike()
+	bike.Add(Component{Constructor: NewHandler})
+	bike.Add(Component{
+		Constructor: func() (*PrometheusMetrics, error) { return nil, errors.New("error") },
+		Interfaces:  []any{(*Metrics)(nil)},
+		Scope:       Prototype,
+	})
+	// When
+	_, startErr := bike.Start()
+	// Then
+	if startErr == nil || startErr.ErrorCode() != ConstructorReturnNotNilError {
+		t.Errorf("Start must return ConstructorReturnNotNilError error")
+	}
+}
+
+func TestStart_GivenOptionalDependencyWithMissingDependency_WhenStart_ThenReturnError(t *testing.T) {
+	// Given
+	bike := NewBike()
+	bike.Add(Component{Constructor: NewHandler})
+	bike.Add(Component{
+		Constructor: func(a *A) *PrometheusMetrics { return &PrometheusMetrics{} },
+		Interfaces:  []any{(*Metrics)(nil)},
+		Scope:       Prototype,
+	})
+	// When
+	_, startErr := bike.Start()
+	// Then
+	if startErr == nil || startErr.ErrorCode() != DependencyByTypeNotFound || len(startErr.Path()) != 2 {
+		t.Errorf("Start must return error of registered optional dependency, actual:%v", startErr)
+	}
+}
+
+func TestStart_GivenFallbackAndRegularComponent_WhenStart_ThenInjectRegularComponent(t *testing.T) {
+	// Given
+	bike := NewBike()
+	bike.Add(Component{Constructor: NewHandler})
+	bike.Add(Component{Constructor: func() *NoopMetrics { return &NoopMetrics{} }, Interfaces: []any{(*Metrics)(nil)}, Fallback: true})
+	bike.Add(Component{Constructor: func() *PrometheusMetrics { return &PrometheusMetrics{} }, Interfaces: []any{(*Metrics)(nil)}})
+	// When
+	container, startErr := bike.Start()
+	// Then
+	if startErr != nil {
+		t.Errorf("Start must return nil error. Error:%s", startErr.Error())
+		return
+	}
+	metrics, _ := MustResolve[*Handler](container).Metrics.Get()
+	if _, ok := metrics.(*PrometheusMetrics); !ok {
+		t.Errorf("Start must inject regular component instead of fallback")
+	}
+}
+
+func TestStart_GivenOnlyFallbackComponent_WhenStart_ThenInjectFallback(t *testing.T) {
+	// Given
+	bike := NewBike()
+	bike.Add(Component{Constructor: NewHandler})
+	bike.Add(Component{Constructor: func() *NoopMetrics { return &NoopMetrics{} }, Interfaces: []any{(*Metrics)(nil)}, Fallback: true})
+	// When
+	container, startErr := bike.Start()
+	// Then
+	if startErr != nil {
+		t.Errorf("Start must return nil error. Error:%s", startErr.Error())
+		return
+	}
+	metrics, _ := MustResolve[*Handler](container).Metrics.Get()
+	if _, ok := metrics.(*NoopMetrics); !ok {
+		t.Errorf("Start must inject fallback component")
+	}
+}
@@ -0,0 +1,26 @@
+package bike
+
+import (
+	"fmt"
+	"runtime/debug"
+)
+
+// callRecovering call function of component, return an error with errorCode, panic value and stack trace
+// when function panics. step is the name of the function called on errors
+func callRecovering(component *Component, errorCode ErrorCode, step string, function func()) (err *Error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = &Error{
+				messageError: fmt.Sprintf("Error on Component %s. %s panicked:[%v], %s", componentDescription(component), step, recovered, constructorDescription(component)),
+				errorCode:    errorCode,
+				stack:        string(debug.Stack()),
+				componentID:  component.ID}
+			// Panics with an error value can be unwrapped
+			if recoveredErr, ok := recovered.(error); ok {
+				err.cause = recoveredErr
+			}
+		}
+	}()
+	function()
+	return nil
+}
@@ -0,0 +1,141 @@
+package bike
+
+import (
+	"strings"
+	"testing"
+)
+
+type PanicComponent struct{}
+
+func NewPanicComponent() *PanicComponent {
+	panic("constructor panic")
+}
+
+type PanicLifecycle struct{}
+
+func NewPanicLifecycle() *PanicLifecycle {
+	return &PanicLifecycle{}
+}
+
+func (_self *PanicLifecycle) Init() {
+	panic("post construct panic")
+}
+
+func (_self *PanicLifecycle) Close() {
+	panic("destroy panic")
+}
+
+type PanicDependent struct{}
+
+func NewPanicDependent(dependency *PanicComponent) *PanicDependent {
+	return &PanicDependent{}
+}
+
+func TestStart_GivenConstructorPanics_WhenStart_ThenReturnConstructorPanicked(t *testing.T) {
+	// Given
+	bike := NewBike()
+	bike.Add(Component{ID: "Panic", Constructor: NewPanicComponent})
+	// When
+	_, err := bike.Start()
+	// Then
+	if err == nil || err.ErrorCode() != ConstructorPanicked {
+		t.Error("Start must return ConstructorPanicked error")
+		return
+	}
+	expected := "Error on Component ID:[Panic]. Constructor panicked:[constructor panic], Constructor:[github.com/kybsa/bike.NewPanicComponent]"
+	if err.Error() != expected {
+		t.Errorf("Error must contain ID, panic value and constructor. Error:%s", err.Error())
+	}
+	if !strings.Contains(err.Stack(), "bike.NewPanicComponent") {
+		t.Errorf("Error must contain stack trace. Stack:%s", err.Stack())
+	}
+}
+
+func TestStart_GivenDependencyPanics_WhenStart_ThenReturnConstructorPanickedWithStack(t *testing.T) {
+	// Given
+	bike := NewBike()
+	bike.Add(Component{Constructor: NewPanicComponent, Lazy: true})
+	bike.Add(Component{Constructor: NewPanicDependent})
+	// When
+	_, err := bike.Start()
+	// Then
+	if err == nil || err.ErrorCode() != ConstructorPanicked || !strings.Contains(err.Stack(), "bike.NewPanicComponent") {
+		t.Error("Start must return ConstructorPanicked error with stack of dependency")
+	}
+}
+
+func TestResolve_GivenScopedConstructorPanics_WhenResolve_ThenReturnErrorAndReleaseContext(t *testing.T) {
+	for _, scope := range []Scope{Prototype, CustomScope} {
+		// Given
+		bike := NewBike()
+		_ = bike.AddCustomScope(CustomScope, "custom")
+		bike.Add(Component{Constructor: NewPanicComponent, Scope: scope})
+		container, _ := bike.Start()
+		// When
+		_, err := ResolveInContext[*PanicComponent](container, scope, "id")
+		_, errAgain := ResolveInContext[*PanicComponent](container, scope, "id")
+		// Then
+		if err == nil || err.ErrorCode() != ConstructorPanicked || errAgain == nil || errAgain.ErrorCode() != ConstructorPanicked {
+			t.Errorf("Resolve must return ConstructorPanicked error on scope %s", scope.String())
+		}
+	}
+}
+
+func TestStart_GivenPostConstructPanics_WhenStart_ThenReturnPostConstructPanicked(t *testing.T) {
+	// Given
+	bike := NewBike()
+	bike.Add(Component{Constructor: NewPanicLifecycle, PostConstruct: "Init"})
+	// When
+	_, err := bike.Start()
+	// Then
+	if err == nil || err.ErrorCode() != PostConstructPanicked || len(err.Stack()) == 0 {
+		t.Error("Start must return PostConstructPanicked error")
+	}
+}
+
+func TestStop_GivenDestroyPanics_WhenStop_ThenReturnDestroyPanickedAndDestroyOthers(t *testing.T) {
+	// Given
+	bike := NewBike()
+	bike.Add(Component{Constructor: NewLifecycleComponent})
+	bike.Add(Component{Constructor: NewPanicLifecycle, Destroy: "Close"})
+	container, _ := bike.Start()
+	component, _ := Resolve[*LifecycleComponent](container)
+	// When
+	err := container.Stop()
+	// Then
+	if err == nil || len(err.Errors()) != 1 || err.Errors()[0].ErrorCode() != DestroyPanicked {
+		t.Error("Stop must return DestroyPanicked error")
+	}
+	if component.destroyed != 1 {
+		t.Error("Stop must destroy other components when a Destroy panics")
+	}
+}
+
+func TestAddDecorator_GivenDecoratorPanics_WhenStart_ThenReturnConstructorPanicked(t *testing.T) {
+	// Given
+	bike := NewBike()
+	bike.Add(Component{Constructor: NewPostgresRepository, Interfaces: []any{(*Repository)(nil)}})
+	bike.Add(Component{Constructor: NewRepositoryHelper})
+	bike.AddDecorator(func(inner Repository) Repository { panic("decorator panic") })
+	// When
+	_, err := bike.Start()
+	// Then
+	if err == nil || err.ErrorCode() != ConstructorPanicked || !strings.Contains(err.Error(), "Decorator:[") {
+		t.Error("Start must return ConstructorPanicked error")
+	}
+}
+
+func TestAddDecorator_GivenDecoratorDependencyPanics_WhenStart_ThenReturnConstructorPanickedWithStack(t *testing.T) {
+	// Given
+	bike := NewBike()
+	bike.Add(Component{Constructor: NewPostgresRepository, Interfaces: []any{(*Repository)(nil)}})
+	bike.Add(Component{Constructor: NewRepositoryHelper})
+	bike.Add(Component{Constructor: NewPanicComponent, Lazy: true})
+	bike.AddDecorator(func(inner Repository, dependency *PanicComponent) Repository { return inner })
+	// When
+	_, err := bike.Start()
+	// Then
+	if err == nil || err.ErrorCode() != ConstructorPanicked || !strings.Contains(err.Stack(), "bike.NewPanicComponent") {
+		t.Error("Start must return ConstructorPanicked error with stack of dependency")
+	}
+}
@@ -0,0 +1,50 @@
+package bike
+
+import "reflect"
+
+// Provider return an instance of T each time it's called: a new instance when T is a Prototype component
+// or the instance of the context where the provider was injected when T is a custom scope component.
+// A provider called by constructors or PostConstruct while its context is being resolved reuses that resolution,
+// it must not be called from other goroutines until the resolution finishes
+type Provider[T any] func() (T, error)
+
+// ContextProvider return the instance of T for a context of a custom scope
+type ContextProvider[T any] func(scope Scope, idContext string) (T, error)
+
+var (
+	errorType = reflect.TypeOf((*error)(nil)).Elem()
+	scopeType = reflect.TypeOf(Singleton)
+)
+
+// isProviderType return true when _type is a function without arguments, or with scope and context id arguments,
+// that return an instance and an error
+func isProviderType(_type reflect.Type) bool {
+	if _type.Kind() != reflect.Func || _type.NumOut() != 2 || _type.Out(1) != errorType {
+		return false
+	}
+	if _type.NumIn() == 2 {
+		return _type.In(0) == scopeType && _type.In(1).Kind() == reflect.String
+	}
+	return _type.NumIn() == 0
+}
+
+// resolveProvider return a function to get instances of type returned by provider type
+func (_self *Container) resolveProvider(_type reflect.Type, qualifier string, module *Module, resolution *resolution) interface{} {
+	instanceType := _type.Out(0)
+	provider := reflect.MakeFunc(_type, func(args []reflect.Value) []reflect.Value {
+		providerScope, providerIDContext := resolution.scope, resolution.idContext
+		if len(args) == 2 {
+			providerScope, providerIDContext = args[0].Interface().(Scope), args[1].String()
+		}
+		instanceValue := reflect.New(instanceType).Elem()
+		errorValue := reflect.New(errorType).Elem()
+		instance, err := _self.boundTo(resolution).instanceByType(instanceType, qualifier, module, providerScope, providerIDContext)
+		if err != nil {
+			errorValue.Set(reflect.ValueOf(err))
+		} else if instance != nil {
+			instanceValue.Set(reflect.ValueOf(instance))
+		}
+		return []reflect.Value{instanceValue, errorValue}
+	})
+	return provider.Interface()
+}
@@ -0,0 +1,198 @@
+package bike
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type ProviderConsumer struct {
+	Provider        Provider[*StructComponent]
+	FuncProvider    func() (*StructComponent, error)
+	ContextProvider ContextProvider[*StructComponent]
+}
+
+func NewProviderConsumer(provider Provider[*StructComponent], funcProvider func() (*StructComponent, error), contextProvider ContextProvider[*StructComponent]) *ProviderConsumer {
+	return &ProviderConsumer{Provider: provider, FuncProvider: funcProvider, ContextProvider: contextProvider}
+}
+
+func TestStart_GivenSingletonWithProviderOfPrototype_WhenCallProvider_ThenReturnNewInstances(t *testing.T) {
+	// Given
+	bike := NewBike()
+	bike.Add(Component{Constructor: NewProviderConsumer})
+	bike.Add(Component{Constructor: NewComponent, Scope: Prototype})
+	container, startErr := bike.Start()
+	if startErr != nil {
+		t.Errorf("Start must return nil error. Error:%s", startErr.Error())
+		return
+	}
+	consumer := MustResolve[*ProviderConsumer](container)
+	// When
+	instance1, err1 := consumer.Provider()
+	instance2, err2 := consumer.FuncProvider()
+	// Then
+	if err1 != nil || err2 != nil {
+		t.Errorf("Provider must return nil error")
+	}
+	if instance1 == nil || instance2 == nil || instance1 == instance2 {
+		t.Errorf("Provider must return new prototype instances")
+	}
+}
+
+func TestStart_GivenSingletonWithContextProvider_WhenCallProvider_ThenReturnInstanceOfContext(t *testing.T) {
+	// Given
+	bike := NewBike()
+	_ = bike.AddCustomScope(CustomScope, "name")
+	bike.Add(Component{Constructor: NewProviderConsumer})
+	bike.Add(Component{Constructor: NewComponent, Scope: CustomScope})
+	container, _ := bike.Start()
+	consumer := MustResolve[*ProviderConsumer](container)
+	// When
+	instance1, err1 := consumer.ContextProvider(CustomScope, "id1")
+	instance2, _ := consumer.ContextProvider(CustomScope, "id1")
+	instance3, _ := consumer.ContextProvider(CustomScope, "id2")
+	// Then
+	if err1 != nil {
+		t.Errorf("ContextProvider must return nil error")
+	}
+	if instance1 != instance2 || instance1 == instance3 {
+		t.Errorf("ContextProvider must return instance by context")
+	}
+	if _, err := consumer.Provider(); err == nil || err.(*Error).ErrorCode() != InvalidScope {
+		t.Errorf("Provider of singleton must return InvalidScope error for custom scope component")
+	}
+}
+
+type ScopedProviderConsumer struct {
+	Provider Provider[*StructComponent] `bike:"inject"`
+}
+
+func TestInstanceByTypeAndIDContext_GivenProviderOnCustomScope_WhenCallProvider_ThenReturnInstanceOfContext(t *testing.T) {
+	// Given
+	bike := NewBike()
+	_ = bike.AddCustomScope(CustomScope, "name")
+	bike.Add(Component{Constructor: func() *ScopedProviderConsumer { return &ScopedProviderConsumer{} }, Scope: CustomScope})
+	bike.Add(Component{Constructor: NewComponent, Scope: CustomScope})
+	container, _ := bike.Start()
+	consumer, _ := ResolveInContext[*ScopedProviderConsumer](container, CustomScope, "id")
+	// When
+	instance, err := consumer.Provider()
+	// Then
+	expected, _ := ResolveInContext[*StructComponent](container, CustomScope, "id")
+	if err != nil || instance != expected {
+		t.Errorf("Provider must return instance of context where it was injected")
+	}
+}
+
+type EagerProviderConsumer struct {
+	Provider  Provider[*StructComponent] `bike:"inject"`
+	Instance  *StructComponent
+	FromField *StructComponent
+}
+
+func NewEagerProviderConsumer(provider Provider[*StructComponent], contextProvider ContextProvider[*StructComponent]) (*EagerProviderConsumer, error) {
+	instance, err := provider()
+	if err != nil {
+		return nil, err
+	}
+	if other, _ := contextProvider(CustomScope, "id"); other != instance {
+		return nil, errors.New("ContextProvider must return instance of same context")
+	}
+	return &EagerProviderConsumer{Instance: instance}, nil
+}
+
+func (_self *EagerProviderConsumer) PostConstruct(container *Container) error {
+	instance, err := _self.Provider()
+	_self.FromField = instance
+	return err
+}
+
+func TestInstanceByTypeAndIDContext_GivenProviderCalledWhileResolvingContext_WhenInstanceByTypeAndIDContext_ThenReturnInstanceOfContext(t *testing.T) {
+	// Given
+	bike := NewBike()
+	_ = bike.AddCustomScope(CustomScope, "name")
+	bike.Add(Component{Constructor: NewEagerProviderConsumer, Scope: CustomScope})
+	bike.Add(Component{Constructor: NewComponent, Scope: CustomScope})
+	container, _ := bike.Start()
+	consumers := make(chan *EagerProviderConsumer, 1)
+	errs := make(chan *Error, 1)
+	// When
+	go func() {
+		consumer, err := ResolveInContext[*EagerProviderConsumer](container, CustomScope, "id")
+		consumers <- consumer
+		errs <- err
+	}()
+	// Then
+	select {
+	case consumer := <-consumers:
+		if err := <-errs; err != nil {
+			t.Errorf("ResolveInContext must return nil error. Error:%s", err.Error())
+			return
+		}
+		expected, _ := ResolveInContext[*StructComponent](container, CustomScope, "id")
+		if consumer.Instance != expected || consumer.FromField != expected {
+			t.Errorf("Provider must return instance of context being resolved")
+		}
+	case <-time.After(time.Second):
+		t.Errorf("Provider called while resolving its context must not deadlock")
+	}
+}
+
+func TestStart_GivenProviderOfMissingComponent_WhenCallProvider_ThenReturnError(t *testing.T) {
+	// Given
+	bike := NewBike()
+	bike.Add(Component{Constructor: NewProviderConsumer})
+	container, _ := bike.Start()
+	consumer := MustResolve[*ProviderConsumer](container)
+	// When
+	instance, err := consumer.Provider()
+	// Then
+	if err == nil || instance != nil {
+		t.Errorf("Provider must return an error")
+	}
+}
+
+type ProvidedA struct {
+	b Provider[*ProvidedB]
+}
+
+type ProvidedB struct {
+	a *ProvidedA
+}
+
+func TestStart_GivenCycleWithProvider_WhenStart_ThenReturnNilError(t *testing.T) {
+	// Given
+	bike := NewBike()
+	bike.Add(Component{Constructor: func(b Provider[*ProvidedB]) *ProvidedA { return &ProvidedA{b: b} }})
+	bike.Add(Component{Constructor: func(a *ProvidedA) *ProvidedB { return &ProvidedB{a: a} }})
+	// When
+	container, startErr := bike.Start()
+	// Then
+	if startErr != nil {
+		t.Errorf("Start must return nil error. Error:%s", startErr.Error())
+		return
+	}
+	b, _ := MustResolve[*ProvidedA](container).b()
+	if b != MustResolve[*ProvidedB](container) {
+		t.Errorf("Provider must return singleton")
+	}
+}
+
+func TestIsProviderType_GivenFunctionWithInvalidArguments_WhenIsProviderType_ThenReturnFalse(t *testing.T) {
+	// Given
+	invalidTypes := []any{
+		func(string) (*A, error) { return nil, nil },
+		func(Scope, int) (*A, error) { return nil, nil },
+		func() *A { return nil },
+		"string",
+	}
+	for _, invalidType := range invalidTypes {
+		// When
+		actual := isProviderType(reflect.TypeOf(invalidType))
+		// Then
+		if actual {
+			t.Errorf("isProviderType must return false for %T", invalidType)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package bike
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Resolve return the instance of type T
+func Resolve[T any](container *Container) (T, *Error) {
+	return ResolveInContext[T](container, Singleton, "0")
+}
+
+// ResolveByID return the instance of component with id, the instance must be of type T
+func ResolveByID[T any](container *Container, id string) (T, *Error) {
+	return ResolveByIDInContext[T](container, id, Singleton, "0")
+}
+
+// ResolveInContext return the instance of type T by scope and idContext
+func ResolveInContext[T any](container *Container, scope Scope, idContext string) (T, *Error) {
+	instance, err := container.instanceByType(reflect.TypeOf((*T)(nil)).Elem(), "", nil, scope, idContext)
+	return castInstance[T](instance, err)
+}
+
+// ResolveQualified return the instance of type T with ID or Qualifier equal to qualifier
+func ResolveQualified[T any](container *Container, qualifier string) (T, *Error) {
+	instance, err := container.instanceByType(reflect.TypeOf((*T)(nil)).Elem(), qualifier, nil, Singleton, "0")
+	return castInstance[T](instance, err)
+}
+
+// ResolveByIDInContext return the instance of component with id by scope and idContext, the instance must be of type T
+func ResolveByIDInContext[T any](container *Container, id string, scope Scope, idContext string) (T, *Error) {
+	instance, err := container.instanceByID(id, scope, idContext)
+	return castInstance[T](instance, err)
+}
+
+// MustResolve return the instance of type T, panic if the instance can't be resolved
+func MustResolve[T any](container *Container) T {
+	instance, err := Resolve[T](container)
+	if err != nil {
+		panic(err)
+	}
+	return instance
+}
+
+// MustResolveByID return the instance of component with id, panic if the instance can't be resolved
+func MustResolveByID[T any](container *Container, id string) T {
+	instance, err := ResolveByID[T](container, id)
+	if err != nil {
+		panic(err)
+	}
+	return instance
+}
+
+// castInstance return instance as T. A nil instance, returned by a constructor of an interface type,
+// is the zero value of T when T is an interface
+func castInstance[T any](instance interface{}, err *Error) (T, *Error) {
+	var zero T
+	if err != nil {
+		return zero, err
+	}
+	_type := reflect.TypeOf((*T)(nil)).Elem()
+	if instance == nil {
+		if _type.Kind() == reflect.Interface {
+			return zero, nil
+		}
+		return zero, &Error{
+			messageError:   fmt.Sprintf("Nil instance can't be converted to %s", getTypeName(_type)),
+			errorCode:      InstanceTypeMismatch,
+			dependencyType: _type}
+	}
+	typedInstance, ok := instance.(T)
+	if !ok {
+		return zero, &Error{
+			messageError:   fmt.Sprintf("Instance of type %s can't be converted to %s", getTypeName(reflect.TypeOf(instance)), getTypeName(_type)),
+			errorCode:      InstanceTypeMismatch,
+			dependencyType: _type}
+	}
+	return typedInstance, nil
+}
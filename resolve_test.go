@@ -0,0 +1,154 @@
+package bike
+
+import "testing"
+
+func TestResolve_GivenComponent_WhenResolve_ThenReturnTypedInstance(t *testing.T) {
+	// Given
+	bike := NewBike()
+	bike.Add(Component{Constructor: NewComponent})
+	container, _ := bike.Start()
+	// When
+	instance, err := Resolve[*StructComponent](container)
+	// Then
+	if err != nil {
+		t.Errorf("Resolve must return nil error")
+	}
+	if instance == nil {
+		t.Errorf("Resolve must return not nil instance")
+	}
+}
+
+func TestResolve_GivenConstructorReturnInterface_WhenResolve_ThenReturnTypedInstance(t *testing.T) {
+	// Given
+	bike := NewBike()
+	bike.Add(Component{Constructor: NewInterfaceComponent})
+	container, _ := bike.Start()
+	// When
+	instance, err := Resolve[InterfaceComponent](container)
+	// Then
+	if err != nil {
+		t.Errorf("Resolve must return nil error")
+	}
+	if instance == nil {
+		t.Errorf("Resolve must return not nil instance")
+	}
+}
+
+func TestResolve_GivenNoComponent_WhenResolve_ThenReturnDependencyByTypeNotFound(t *testing.T) {
+	// Given
+	container, _ := NewBike().Start()
+	// When
+	instance, err := Resolve[*StructComponent](container)
+	// Then
+	if err == nil || err.ErrorCode() != DependencyByTypeNotFound {
+		t.Errorf("Resolve must return DependencyByTypeNotFound error")
+	}
+	if instance != nil {
+		t.Errorf("Resolve must return nil instance")
+	}
+}
+
+func TestResolveByID_GivenComponentWithID_WhenResolveByID_ThenReturnTypedInstance(t *testing.T) {
+	// Given
+	bike := NewBike()
+	bike.Add(Component{ID: "IdComponent", Constructor: NewComponent})
+	container, _ := bike.Start()
+	// When
+	instance, err := ResolveByID[*StructComponent](container, "IdComponent")
+	// Then
+	if err != nil || instance == nil {
+		t.Errorf("ResolveByID must return not nil instance")
+	}
+}
+
+func TestResolveByID_GivenComponentOfOtherType_WhenResolveByID_ThenReturnInstanceTypeMismatch(t *testing.T) {
+	// Given
+	bike := NewBike()
+	bike.Add(Component{ID: "IdComponent", Constructor: NewComponent})
+	container, _ := bike.Start()
+	// When
+	_, err := ResolveByID[*A](container, "IdComponent")
+	// Then
+	if err == nil || err.ErrorCode() != InstanceTypeMismatch {
+		t.Errorf("ResolveByID must return InstanceTypeMismatch error")
+	}
+}
+
+func NewNilInterfaceComponent() InterfaceComponent {
+	return nil
+}
+
+func TestResolve_GivenConstructorReturnNilInterface_WhenResolve_ThenReturnZeroValueOrInstanceTypeMismatch(t *testing.T) {
+	// Given
+	bike := NewBike()
+	bike.Add(Component{ID: "IdComponent", Constructor: NewNilInterfaceComponent})
+	container, _ := bike.Start()
+	// When
+	instance, err := Resolve[InterfaceComponent](container)
+	_, errByID := ResolveByID[*StructComponent](container, "IdComponent")
+	// Then
+	if err != nil || instance != nil {
+		t.Errorf("Resolve must return nil instance of interface type")
+	}
+	if errByID == nil || errByID.ErrorCode() != InstanceTypeMismatch {
+		t.Errorf("ResolveByID must return InstanceTypeMismatch error, actual:%v", errByID)
+	}
+}
+
+func TestResolveInContext_GivenCustomScopeComponent_WhenResolveInContext_ThenReturnSameInstanceOnContext(t *testing.T) {
+	// Given
+	bike := NewBike()
+	_ = bike.AddCustomScope(CustomScope, "name")
+	bike.Add(Component{ID: "IdComponent", Constructor: NewComponent, Scope: CustomScope})
+	container, _ := bike.Start()
+	// When
+	instance1, err1 := ResolveInContext[*StructComponent](container, CustomScope, "id")
+	instance2, err2 := ResolveByIDInContext[*StructComponent](container, "IdComponent", CustomScope, "id")
+	// Then
+	if err1 != nil || err2 != nil {
+		t.Errorf("ResolveInContext must return nil error")
+	}
+	if instance1 != instance2 {
+		t.Errorf("ResolveInContext must return same instance on same context")
+	}
+}
+
+func TestMustResolve_GivenComponent_WhenMustResolve_ThenReturnInstance(t *testing.T) {
+	// Given
+	bike := NewBike()
+	bike.Add(Component{ID: "IdComponent", Constructor: NewComponent})
+	container, _ := bike.Start()
+	// When
+	instance := MustResolve[*StructComponent](container)
+	instanceByID := MustResolveByID[*StructComponent](container, "IdComponent")
+	// Then
+	if instance == nil || instance != instanceByID {
+		t.Errorf("MustResolve must return same singleton instance")
+	}
+}
+
+func TestMustResolve_GivenNoComponent_WhenMustResolve_ThenPanic(t *testing.T) {
+	// Given
+	container, _ := NewBike().Start()
+	defer func() {
+		// Then
+		if recover() == nil {
+			t.Errorf("MustResolve must panic")
+		}
+	}()
+	// When
+	MustResolve[*StructComponent](container)
+}
+
+func TestMustResolveByID_GivenNoComponent_WhenMustResolveByID_ThenPanic(t *testing.T) {
+	// Given
+	container, _ := NewBike().Start()
+	defer func() {
+		// Then
+		if recover() == nil {
+			t.Errorf("MustResolveByID must panic")
+		}
+	}()
+	// When
+	MustResolveByID[*StructComponent](container, "any-id")
+}
@@ -0,0 +1,196 @@
+package bike
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// Runner is implemented by long-running singleton components, like servers or consumers, launched by Container.Run.
+// Run must block until ctx is done and return, an error returned before ctx is done shuts down the container
+type Runner interface {
+	Run(ctx context.Context) error
+}
+
+// shutdownContextKey is the key of the shutdown on the context passed to runners
+type shutdownContextKey struct{}
+
+// shutdown hold the context of the shutdown once Container.Run started it
+type shutdown struct {
+	mutex sync.Mutex
+	ctx   context.Context
+}
+
+// valuesContext is a context with values of parent that is never done
+type valuesContext struct {
+	context.Context
+}
+
+func (_self valuesContext) Deadline() (time.Time, bool) {
+	return time.Time{}, false
+}
+
+func (_self valuesContext) Done() <-chan struct{} {
+	return nil
+}
+
+func (_self valuesContext) Err() error {
+	return nil
+}
+
+// ShutdownContext return the context of the shutdown started when ctx passed to Runner.Run is done, its deadline
+// is the end of the shutdown timeout. Runners use it to bound their graceful shutdown.
+// Return a context never done when ctx wasn't created by Container.Run or ctx isn't done yet
+func ShutdownContext(ctx context.Context) context.Context {
+	if shutdown, ok := ctx.Value(shutdownContextKey{}).(*shutdown); ok {
+		shutdown.mutex.Lock()
+		defer shutdown.mutex.Unlock()
+		if shutdown.ctx != nil {
+			return shutdown.ctx
+		}
+	}
+	return context.Background()
+}
+
+// Run start bike and run the container until SIGINT, SIGTERM or ctx is done, see Container.Run
+func Run(ctx context.Context, bike *Bike) *Error {
+	container, err := bike.StartContext(ctx)
+	if err != nil {
+		return err
+	}
+	return container.Run(ctx)
+}
+
+// Run launch every created singleton implementing Runner and wait until SIGINT, SIGTERM, ctx is done or a Runner fails.
+// Then cancels the context of runners, waits for them and stops the container within shutdown timeout.
+// Return an error listing Runner components that failed, panicked or didn't return and the error of Stop
+func (_self *Container) Run(ctx context.Context) *Error {
+	signalCtx, stopSignals := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stopSignals()
+	stopCtx, stop := context.WithCancel(signalCtx)
+	defer stop()
+	// The context of runners is done once the shutdown started, not when ctx is done
+	shutdown := &shutdown{}
+	runCtx, cancel := context.WithCancel(context.WithValue(valuesContext{ctx}, shutdownContextKey{}, shutdown))
+	defer cancel()
+
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+	results := make(map[*Component]*Error)
+	runners := _self.runners()
+	for _, component := range runners {
+		wg.Add(1)
+		go func(component *Component, runner Runner) {
+			defer wg.Done()
+			err := callRun(runCtx, component, runner)
+			if err != nil {
+				stop()
+			}
+			mutex.Lock()
+			defer mutex.Unlock()
+			results[component] = err
+		}(component, component.instanceValue.Interface().(Runner))
+	}
+	<-stopCtx.Done()
+
+	// Shutdown, runners first and then Destroy methods until shutdown timeout
+	var shutdownCtx context.Context
+	var cancelShutdown context.CancelFunc
+	if _self.shutdownTimeout > 0 {
+		shutdownCtx, cancelShutdown = context.WithTimeout(context.Background(), _self.shutdownTimeout)
+	} else {
+		shutdownCtx, cancelShutdown = context.WithCancel(context.Background())
+	}
+	defer cancelShutdown()
+	shutdown.mutex.Lock()
+	shutdown.ctx = shutdownCtx
+	shutdown.mutex.Unlock()
+	cancel()
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-shutdownCtx.Done():
+	}
+
+	mutex.Lock()
+	errors := make([]*Error, 0)
+	for _, component := range runners {
+		err, finished := results[component]
+		if !finished {
+			err = &Error{
+				messageError: fmt.Sprintf("Error on Component %s. Run didn't return before shutdown timeout:[%s]", componentDescription(component), _self.shutdownTimeout),
+				errorCode:    ShutdownTimeout,
+				cause:        context.DeadlineExceeded,
+				componentID:  component.ID}
+		}
+		if err != nil {
+			errors = append(errors, err)
+		}
+	}
+	mutex.Unlock()
+
+	stopped := make(chan *Error, 1)
+	go func() {
+		stopped <- _self.StopContext(shutdownCtx)
+	}()
+	select {
+	case stopErr := <-stopped:
+		if stopErr != nil {
+			errors = append(errors, stopErr)
+		}
+	case <-shutdownCtx.Done():
+		errors = append(errors, &Error{
+			messageError: fmt.Sprintf("Error on Stop container. Destroy methods didn't finish before shutdown timeout:[%s]", _self.shutdownTimeout),
+			errorCode:    ShutdownTimeout,
+			cause:        context.DeadlineExceeded})
+	}
+	return joinErrors(RunnerReturnError, "Error on Run", errors)
+}
+
+// runners return created singletons implementing Runner in registration order
+func (_self *Container) runners() []*Component {
+	runners := make([]*Component, 0)
+	for _, component := range _self.components {
+		if component.Scope != Singleton {
+			continue
+		}
+		component.mutex.Lock()
+		instanceValue := component.instanceValue
+		component.mutex.Unlock()
+		if instanceValue == nil {
+			continue
+		}
+		if _, ok := instanceValue.Interface().(Runner); ok {
+			runners = append(runners, component)
+		}
+	}
+	return runners
+}
+
+// callRun call Run of runner, return an error when Run panics or returns an error other than the error of ctx once ctx is done
+func callRun(ctx context.Context, component *Component, runner Runner) *Error {
+	var err error
+	panicErr := callRecovering(component, RunnerPanicked, "Run", func() {
+		err = runner.Run(ctx)
+	})
+	if panicErr != nil {
+		return panicErr
+	}
+	if err != nil && (ctx.Err() == nil || !errors.Is(err, ctx.Err())) {
+		return &Error{
+			messageError: fmt.Sprintf("Error on Component %s. Run return an error:[%s]", componentDescription(component), err.Error()),
+			errorCode:    RunnerReturnError,
+			cause:        err,
+			componentID:  component.ID}
+	}
+	return nil
+}
@@ -0,0 +1,256 @@
+package bike
+
+import (
+	"context"
+	"errors"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+)
+
+type RunnerComponent struct {
+	run        func(ctx context.Context) error
+	destroyCtx context.Context
+	destroyErr error
+}
+
+func (_self *RunnerComponent) Run(ctx context.Context) error {
+	return _self.run(ctx)
+}
+
+func (_self *RunnerComponent) Destroy(ctx context.Context) error {
+	_self.destroyCtx = ctx
+	return _self.destroyErr
+}
+
+type BlockingDisposer struct {
+	blocked chan struct{}
+}
+
+func (_self *BlockingDisposer) Destroy(ctx context.Context) error {
+	<-_self.blocked
+	return nil
+}
+
+// waitDone return ctx error once ctx is done
+func waitDone(ctx context.Context) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestRun_GivenRunnerAndCanceledContext_WhenRun_ThenCancelRunnerAndStop(t *testing.T) {
+	// Given
+	runner := &RunnerComponent{run: waitDone}
+	bike := NewBike()
+	bike.AddInstance(runner)
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(10*time.Millisecond, cancel)
+	// When
+	err := Run(ctx, bike)
+	// Then
+	if err != nil {
+		t.Errorf("Run must return nil error. Error:%s", err.Error())
+	}
+	if runner.destroyCtx == nil {
+		t.Error("Run must stop container")
+	}
+}
+
+func TestRun_GivenInvalidBike_WhenRun_ThenReturnStartError(t *testing.T) {
+	// Given
+	bike := NewBike()
+	bike.Add(Component{})
+	// When
+	err := Run(context.Background(), bike)
+	// Then
+	if err == nil || err.ErrorCode() != ComponentConstructorNull {
+		t.Error("Run must return error of Start")
+	}
+}
+
+func TestRun_GivenSIGTERM_WhenRun_ThenStopContainer(t *testing.T) {
+	// Given
+	runner := &RunnerComponent{run: func(ctx context.Context) error {
+		if err := syscall.Kill(os.Getpid(), syscall.SIGTERM); err != nil {
+			return err
+		}
+		return waitDone(ctx)
+	}}
+	bike := NewBike()
+	bike.AddInstance(runner)
+	container, _ := bike.Start()
+	// When
+	err := container.Run(context.Background())
+	// Then
+	if err != nil {
+		t.Errorf("Run must return nil error. Error:%s", err.Error())
+	}
+	if runner.destroyCtx == nil {
+		t.Error("Run must stop container")
+	}
+}
+
+func TestRun_GivenRunnerReturningError_WhenRun_ThenCancelRunnersAndReturnError(t *testing.T) {
+	// Given
+	runErr := errors.New("address already in use")
+	waiting := &RunnerComponent{run: waitDone}
+	bike := NewBike()
+	bike.AddInstance(waiting, WithID("Waiting"))
+	bike.AddInstance(&RunnerComponent{run: func(ctx context.Context) error { return runErr }}, WithID("Failing"))
+	bike.AddInstance(&RunnerComponent{run: func(ctx context.Context) error { panic("listener closed") }}, WithID("Panicking"))
+	container, _ := bike.Start()
+	// When
+	err := container.Run(context.Background())
+	// Then
+	if err == nil || err.ErrorCode() != RunnerReturnError || len(err.Errors()) != 2 {
+		t.Errorf("Run must return error of every failed Runner, actual:%v", err)
+		return
+	}
+	if err.Errors()[0].ComponentID() != "Failing" || !errors.Is(err, runErr) {
+		t.Errorf("Run must return error returned by Runner, actual:%s", err.Errors()[0].Error())
+	}
+	if err.Errors()[1].ErrorCode() != RunnerPanicked || err.Errors()[1].ComponentID() != "Panicking" {
+		t.Errorf("Run must return Runner panic, actual:%s", err.Errors()[1].Error())
+	}
+	if waiting.destroyCtx == nil {
+		t.Error("Run must stop container")
+	}
+}
+
+func TestRun_GivenRunnerNotReturning_WhenRun_ThenReturnShutdownTimeout(t *testing.T) {
+	// Given
+	blocked := make(chan struct{})
+	defer close(blocked)
+	bike := NewBike()
+	bike.SetShutdownTimeout(10 * time.Millisecond)
+	bike.AddInstance(&RunnerComponent{run: func(ctx context.Context) error {
+		<-blocked
+		return nil
+	}}, WithID("Blocked"))
+	container, _ := bike.Start()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	// When
+	err := container.Run(ctx)
+	// Then
+	if err == nil || err.Errors()[0].ErrorCode() != ShutdownTimeout || err.Errors()[0].ComponentID() != "Blocked" {
+		t.Errorf("Run must return ShutdownTimeout, actual:%v", err)
+	}
+}
+
+func TestRun_GivenDestroyReturningError_WhenRun_ThenReturnStopError(t *testing.T) {
+	// Given
+	destroyErr := errors.New("flush failed")
+	bike := NewBike()
+	bike.AddInstance(&RunnerComponent{run: func(ctx context.Context) error { return nil }, destroyErr: destroyErr})
+	container, _ := bike.Start()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	// When
+	err := container.Run(ctx)
+	// Then
+	if err == nil || len(err.Errors()) != 1 || !errors.Is(err, DestroyReturnError) || !errors.Is(err, destroyErr) {
+		t.Errorf("Run must return Stop error, actual:%v", err)
+	}
+}
+
+func TestRun_GivenDestroyNotFinishing_WhenRun_ThenReturnShutdownTimeout(t *testing.T) {
+	// Given
+	blocked := make(chan struct{})
+	defer close(blocked)
+	bike := NewBike()
+	bike.SetShutdownTimeout(10 * time.Millisecond)
+	bike.AddInstance(&BlockingDisposer{blocked: blocked})
+	container, _ := bike.Start()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	// When
+	err := container.Run(ctx)
+	// Then
+	if err == nil || len(err.Errors()) != 1 || err.Errors()[0].ErrorCode() != ShutdownTimeout {
+		t.Errorf("Run must return ShutdownTimeout of Stop, actual:%v", err)
+	}
+}
+
+func TestRun_GivenLazyAndPrototypeRunners_WhenRun_ThenRunOnlyCreatedSingletons(t *testing.T) {
+	// Given
+	ran := false
+	newRunner := func() *RunnerComponent {
+		return &RunnerComponent{run: func(ctx context.Context) error {
+			ran = true
+			return nil
+		}}
+	}
+	bike := NewBike()
+	bike.Add(Component{Constructor: newRunner, Lazy: true})
+	bike.Add(Component{Constructor: newRunner, Scope: Prototype})
+	container, _ := bike.Start()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	// When
+	err := container.Run(ctx)
+	// Then
+	if err != nil || ran {
+		t.Error("Run must only launch created singletons")
+	}
+}
+
+func TestShutdownContext_GivenRunner_WhenShutdownTimeoutExpires_ThenShutdownContextIsDone(t *testing.T) {
+	// Given
+	shutdownDone := make(chan struct{})
+	var deadline time.Time
+	var hasDeadline bool
+	bike := NewBike()
+	bike.SetShutdownTimeout(10 * time.Millisecond)
+	bike.AddInstance(&RunnerComponent{run: func(ctx context.Context) error {
+		<-ctx.Done()
+		deadline, hasDeadline = ShutdownContext(ctx).Deadline()
+		<-ShutdownContext(ctx).Done()
+		close(shutdownDone)
+		return nil
+	}})
+	container, _ := bike.Start()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	// When
+	_ = container.Run(ctx)
+	// Then
+	select {
+	case <-shutdownDone:
+	case <-time.After(time.Second):
+		t.Error("ShutdownContext must be done when shutdown timeout expires")
+	}
+	if !hasDeadline || time.Until(deadline) > 10*time.Millisecond {
+		t.Errorf("ShutdownContext must have the deadline of shutdown timeout, actual:%v", deadline)
+	}
+	if ShutdownContext(context.Background()).Done() != nil {
+		t.Error("ShutdownContext of a context not created by Run must never be done")
+	}
+}
+
+func TestRun_GivenContextWithValue_WhenRun_ThenRunnerContextHasValueAndIsDoneOnShutdown(t *testing.T) {
+	// Given
+	var value any
+	var err error
+	bike := NewBike()
+	bike.AddInstance(&RunnerComponent{run: func(ctx context.Context) error {
+		value = ctx.Value(contextKey("key"))
+		<-ctx.Done()
+		err = ctx.Err()
+		return nil
+	}})
+	container, _ := bike.Start()
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), contextKey("key"), "value"))
+	time.AfterFunc(10*time.Millisecond, cancel)
+	// When
+	runErr := container.Run(ctx)
+	// Then
+	if runErr != nil || value != "value" || err != context.Canceled {
+		t.Error("Run must pass values of ctx to runners and cancel them on shutdown")
+	}
+	values := valuesContext{ctx}
+	if _, ok := values.Deadline(); ok || values.Done() != nil || values.Err() != nil {
+		t.Error("valuesContext must never be done")
+	}
+}
@@ -1,6 +1,9 @@
 package bike
 
-import "reflect"
+import (
+	"reflect"
+	"sync"
+)
 
 // Scope Component supported
 type Scope uint8
@@ -23,13 +26,50 @@ func (_self *Scope) String() string {
 
 // Component is a struct with data to create components
 type Component struct {
-	ID                      string
-	Interfaces              []any
-	Scope                   Scope
-	PostConstruct           string
-	Destroy                 string
-	Constructor             interface{}
-	PostStart               string
+	ID            string
+	Interfaces    []any
+	Scope         Scope
+	PostConstruct string
+	Destroy       string
+	Constructor   interface{}
+	PostStart     string
+	// Primary marks the component as the choice when several components are registered by the same type
+	Primary bool
+	// Qualifier to select this component when several components are registered by the same type
+	Qualifier string
+	// Qualifiers by constructor argument index, value is the ID or Qualifier of the dependency to inject
+	Qualifiers map[int]string
+	// Fallback marks the component to be injected only when no other component is registered by the same type
+	Fallback bool
+	// Lazy marks a singleton to be created the first time it's required instead of on Bike.Start.
+	// A lazy singleton can't have PostStart or implement Starter
+	Lazy bool
+	// Conditions evaluated by Bike.Start, the component is registered only when all conditions are true
+	Conditions []Condition
+
 	instanceValue           *reflect.Value
+	decoratedValue          *reflect.Value
 	prototypeInstancesValue []*reflect.Value
+	dependencies            []*Component
+	container               *Container
+	module                  *Module
+	instance                bool
+	mutex                   *sync.Mutex
+}
+
+func (_self *Component) constructorType() reflect.Type {
+	return reflect.TypeOf(_self.Constructor)
+}
+
+func (_self *Component) componentType() reflect.Type {
+	return _self.constructorType().Out(0)
+}
+
+// types return types to registry the component: type returned by constructor and interfaces
+func (_self *Component) types() []reflect.Type {
+	types := []reflect.Type{_self.componentType()}
+	for _, inter := range _self.Interfaces {
+		types = append(types, reflect.TypeOf(inter).Elem())
+	}
+	return types
 }
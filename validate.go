@@ -0,0 +1,79 @@
+package bike
+
+import "fmt"
+
+// Validate check every component without calling constructors. Return an error listing every error found:
+// invalid components and decorators, duplicate IDs, invalid module exports, dependencies not found or ambiguous,
+// circular dependencies. Warnings aren't errors, see Bike.Warnings
+func (_self *Bike) Validate() *Error {
+	container, errors := _self.dryContainer()
+	for _, module := range _self.modules {
+		if err := module.validateExports(container); err != nil {
+			errors = append(errors, err)
+		}
+	}
+	for _, component := range container.components {
+		for _, edge := range container.edgesOf(component) {
+			if edge.err != nil {
+				errors = append(errors, edge.err)
+			}
+			if edge.dependency != nil {
+				component.dependencies = append(component.dependencies, edge.dependency)
+			}
+		}
+	}
+	if _, err := container.sortComponents(); err != nil {
+		errors = append(errors, err)
+	}
+	return joinErrors(InvalidConfiguration, "Invalid configuration", errors)
+}
+
+// dryContainer return a container with copies of valid components registered, constructors aren't called.
+// Return errors of invalid components, decorators, decorator chains and duplicate IDs. Bike and its components aren't modified
+func (_self *Bike) dryContainer() (*Container, []*Error) {
+	errors := make([]*Error, 0)
+	container := _self.newContainer(nil)
+	container.decorators = make([]any, 0)
+	for _, decorator := range _self.decorators {
+		if err := validateDecorator(decorator); err != nil {
+			errors = append(errors, err)
+		} else {
+			container.decorators = append(container.decorators, decorator)
+		}
+	}
+	components := make([]*Component, 0, len(_self.components))
+	for _, component := range _self.components {
+		if err := _self.validateComponent(component); err != nil {
+			errors = append(errors, err)
+		} else {
+			copied := *component
+			components = append(components, &copied)
+		}
+	}
+	container.components = activeComponents(components, _self.profiles)
+	errors = append(errors, duplicateIDs(container.components)...)
+	for _, component := range container.components {
+		container.registry(component)
+		if err := container.validateDecoratorsOf(component); err != nil {
+			errors = append(errors, err)
+		}
+	}
+	return container, errors
+}
+
+// duplicateIDs return an error by each component with an ID already registered
+func duplicateIDs(components []*Component) []*Error {
+	errors := make([]*Error, 0)
+	registered := make(map[string]*Component, len(components))
+	for _, component := range components {
+		if previous, ok := registered[component.ID]; ok {
+			errors = append(errors, &Error{
+				messageError: fmt.Sprintf("Error on Component %s. Duplicate ID, already registered by %s", componentDescription(component), constructorDescription(previous)),
+				errorCode:    DuplicateComponentID,
+				componentID:  component.ID})
+			continue
+		}
+		registered[component.ID] = component
+	}
+	return errors
+}
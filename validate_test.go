@@ -0,0 +1,122 @@
+package bike
+
+import (
+	"reflect"
+	"testing"
+)
+
+type FieldByIDConsumer struct {
+	Helper *RepositoryHelper `bike:"inject,id=UnknownHelper"`
+}
+
+func NewFieldByIDConsumer() *FieldByIDConsumer {
+	return &FieldByIDConsumer{}
+}
+
+func TestValidate_GivenValidBike_WhenValidate_ThenReturnNilWithoutCallConstructors(t *testing.T) {
+	// Given
+	bike := NewBike()
+	bike.AddModule(newRepositoryModule())
+	bike.Add(Component{Constructor: func(repository Repository) *RepositoryService {
+		panic("Constructor must not be called")
+	}})
+	bike.AddDecorator(cacheDecorator)
+	// When
+	err := bike.Validate()
+	// Then
+	if err != nil {
+		t.Errorf("Validate must return nil error. Error:%s", err.Error())
+	}
+}
+
+func TestValidate_GivenInvalidBike_WhenValidate_ThenReturnEveryError(t *testing.T) {
+	// Given
+	bike := NewBike()
+	bike.AddDecorator("invalid")
+	bike.Add(Component{ID: "NilConstructor"})
+	bike.Add(Component{ID: "NotImplemented", Constructor: NewRepositoryHelper, Interfaces: []any{(*Repository)(nil)}})
+	bike.Add(Component{ID: "Duplicated", Constructor: NewQueryCounter})
+	bike.Add(Component{ID: "Duplicated", Constructor: NewQueryCounter})
+	bike.AddModule(&Module{Name: "empty", Exports: []any{(*Repository)(nil)}})
+	bike.Add(Component{ID: "MissingByType", Constructor: NewRepositoryService})
+	bike.Add(Component{ID: "MissingByID", Constructor: NewFieldByIDConsumer})
+	bike.Add(Component{ID: "A", Constructor: NewCycleA})
+	bike.Add(Component{ID: "B", Constructor: NewCycleB})
+	bike.Add(Component{ID: "C", Constructor: NewCycleC})
+	// When
+	err := bike.Validate()
+	// Then
+	if err == nil || err.ErrorCode() != InvalidConfiguration {
+		t.Error("Validate must return InvalidConfiguration error")
+		return
+	}
+	errorCodes := make([]ErrorCode, len(err.Errors()))
+	for index, validationError := range err.Errors() {
+		errorCodes[index] = validationError.ErrorCode()
+	}
+	expected := []ErrorCode{InvalidDecorator, ComponentConstructorNull, InterfaceNotImplemented, DuplicateComponentID,
+		InvalidModuleExport, DependencyByTypeNotFound, DependencyByIDNotFound, CircularDependency}
+	if !reflect.DeepEqual(errorCodes, expected) {
+		t.Errorf("Validate must return every error, actual:%v. Error:%s", errorCodes, err.Error())
+	}
+}
+
+func TestValidate_GivenOptionalDependencies_WhenValidate_ThenReturnNil(t *testing.T) {
+	// Given
+	type OptionalFields struct {
+		ByType *RepositoryHelper `bike:"inject,optional"`
+		ByID   *RepositoryHelper `bike:"inject,optional,id=UnknownHelper"`
+	}
+	bike := NewBike()
+	bike.Add(Component{Constructor: func(helper Optional[*RepositoryHelper], provider Provider[*QueryCounter]) *OptionalFields {
+		return &OptionalFields{}
+	}})
+	// When
+	err := bike.Validate()
+	// Then
+	if err != nil {
+		t.Errorf("Validate must return nil error. Error:%s", err.Error())
+	}
+}
+
+func TestStart_GivenDuplicateID_WhenStart_ThenReturnDuplicateComponentID(t *testing.T) {
+	// Given
+	bike := NewBike()
+	bike.Add(Component{ID: "Duplicated", Constructor: NewQueryCounter})
+	bike.Add(Component{ID: "Duplicated", Constructor: NewRepositoryHelper})
+	// When
+	_, err := bike.Start()
+	// Then
+	if err == nil || err.ErrorCode() != DuplicateComponentID {
+		t.Error("Start must return DuplicateComponentID error")
+	}
+}
+
+func TestStart_GivenDuplicateIDOnInactiveComponent_WhenStart_ThenReturnNil(t *testing.T) {
+	// Given
+	bike := NewBike()
+	bike.SetProfiles("prod")
+	bike.Add(Component{ID: "Database", Constructor: NewSqliteDatabase, Conditions: []Condition{OnProfile("test")}})
+	bike.Add(Component{ID: "Database", Constructor: NewPostgresDatabase, Conditions: []Condition{OnProfile("prod")}})
+	// When
+	_, err := bike.Start()
+	// Then
+	if err != nil {
+		t.Errorf("Start must return nil error. Error:%s", err.Error())
+	}
+}
+
+func TestStart_GivenInterfaceNotImplemented_WhenStart_ThenReturnInterfaceNotImplemented(t *testing.T) {
+	// Given
+	testCases := map[string][]any{"not implemented": {(*Repository)(nil)}, "nil": {nil}}
+	for name, interfaces := range testCases {
+		bike := NewBike()
+		bike.Add(Component{Constructor: NewRepositoryHelper, Interfaces: interfaces})
+		// When
+		_, err := bike.Start()
+		// Then
+		if err == nil || err.ErrorCode() != InterfaceNotImplemented {
+			t.Errorf("Start must return InterfaceNotImplemented error with %s interface", name)
+		}
+	}
+}
@@ -2,8 +2,11 @@
 package web
 
 import (
+	"context"
+	"net/http"
 	"strings"
 
+	"github.com/kybsa/bike"
 	"github.com/kybsa/bike/config"
 )
 
@@ -20,6 +23,48 @@ func (webComponent *GinGonicComponent) Start() error {
 	return webComponent.Engine.Run(webComponent.Addr...)
 }
 
+// Run serve Engine on every Addr until ctx is done and then shut down servers gracefully within the shutdown timeout
+// of container, it's launched by bike.Container.Run.
+// Engine must be an http.Handler like *gin.Engine, otherwise Engine.Run is called and it keeps serving after Run returns
+func (webComponent *GinGonicComponent) Run(ctx context.Context) error {
+	handler, ok := webComponent.Engine.(http.Handler)
+	if !ok {
+		errRun := make(chan error, 1)
+		go func() {
+			errRun <- webComponent.Engine.Run(webComponent.Addr...)
+		}()
+		select {
+		case err := <-errRun:
+			return err
+		case <-ctx.Done():
+			return nil
+		}
+	}
+
+	servers := make([]*http.Server, 0, len(webComponent.Addr))
+	errServe := make(chan error, len(webComponent.Addr))
+	for _, addr := range webComponent.Addr {
+		server := &http.Server{Addr: addr, Handler: handler}
+		servers = append(servers, server)
+		go func(server *http.Server) {
+			errServe <- server.ListenAndServe()
+		}(server)
+	}
+	var err error
+	select {
+	case err = <-errServe:
+	case <-ctx.Done():
+	}
+	// Connections are closed when shutdown timeout of container expires
+	shutdownCtx := bike.ShutdownContext(ctx)
+	for _, server := range servers {
+		if shutdownErr := server.Shutdown(shutdownCtx); err == nil {
+			err = shutdownErr
+		}
+	}
+	return err
+}
+
 func NewGinGonicComponent(engine Engine, configComponent config.ConfigComponent) *GinGonicComponent {
 	addr := []string{"0.0.0.0:8080"}
 	addrConfig, ok := configComponent.Get("GinGonicComponent.Addr")
@@ -1,8 +1,13 @@
 package web
 
 import (
+	"context"
+	"net"
+	"net/http"
 	"testing"
+	"time"
 
+	"github.com/kybsa/bike"
 	"github.com/kybsa/bike/config"
 )
 
@@ -34,3 +39,143 @@ func TestStart_GivenGinGonicComponent_WhenStart_ThenCallRun(t *testing.T) {
 		t.Error("Start must call Run")
 	}
 }
+
+type HandlerEngine struct {
+	EngineStruct
+}
+
+func (handlerEngine *HandlerEngine) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+type BlockingEngine struct {
+	blocked chan struct{}
+}
+
+func (blockingEngine *BlockingEngine) Run(addr ...string) error {
+	<-blockingEngine.blocked
+	return nil
+}
+
+func TestRun_GivenHandlerEngine_WhenContextDone_ThenServeAndShutdown(t *testing.T) {
+	// Given
+	listener, _ := net.Listen("tcp", "127.0.0.1:0")
+	addr := listener.Addr().String()
+	listener.Close()
+	ginGonicComponent := &GinGonicComponent{Engine: &HandlerEngine{}, Addr: []string{addr}}
+	ctx, cancel := context.WithCancel(context.Background())
+	errRun := make(chan error, 1)
+	// When
+	go func() {
+		errRun <- ginGonicComponent.Run(ctx)
+	}()
+	var response *http.Response
+	var errGet error
+	for retry := 0; retry < 50; retry++ {
+		if response, errGet = http.Get("http://" + addr); errGet == nil {
+			response.Body.Close()
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	cancel()
+	// Then
+	if errGet != nil || response.StatusCode != http.StatusNoContent {
+		t.Errorf("Run must serve Engine, error:%v", errGet)
+	}
+	if err := <-errRun; err != nil {
+		t.Errorf("Run must return nil error. Error:%s", err.Error())
+	}
+}
+
+func TestRun_GivenInvalidAddr_WhenRun_ThenReturnError(t *testing.T) {
+	// Given
+	ginGonicComponent := &GinGonicComponent{Engine: &HandlerEngine{}, Addr: []string{"127.0.0.1:invalid"}}
+	// When
+	err := ginGonicComponent.Run(context.Background())
+	// Then
+	if err == nil {
+		t.Error("Run must return listen error")
+	}
+}
+
+func TestRun_GivenEngineNotHandler_WhenRun_ThenCallEngineRun(t *testing.T) {
+	// Given
+	engineStruct := &EngineStruct{}
+	blockingEngine := &BlockingEngine{blocked: make(chan struct{})}
+	defer close(blockingEngine.blocked)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	// When
+	err := (&GinGonicComponent{Engine: engineStruct}).Run(context.Background())
+	errBlocking := (&GinGonicComponent{Engine: blockingEngine}).Run(ctx)
+	// Then
+	if err != nil || !engineStruct.callRun {
+		t.Error("Run must call Engine.Run")
+	}
+	if errBlocking != nil {
+		t.Error("Run must return nil when ctx is done")
+	}
+}
+
+type BlockingHandlerEngine struct {
+	EngineStruct
+	entered chan struct{}
+	blocked chan struct{}
+}
+
+func (handlerEngine *BlockingHandlerEngine) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	close(handlerEngine.entered)
+	<-handlerEngine.blocked
+}
+
+type GinGonicRunner struct {
+	component *GinGonicComponent
+	returned  chan struct{}
+}
+
+func (runner *GinGonicRunner) Run(ctx context.Context) error {
+	defer close(runner.returned)
+	return runner.component.Run(ctx)
+}
+
+func TestRun_GivenSlowConnection_WhenShutdownTimeoutExpires_ThenReturn(t *testing.T) {
+	// Given
+	listener, _ := net.Listen("tcp", "127.0.0.1:0")
+	addr := listener.Addr().String()
+	listener.Close()
+	engine := &BlockingHandlerEngine{entered: make(chan struct{}), blocked: make(chan struct{})}
+	defer close(engine.blocked)
+	runner := &GinGonicRunner{component: &GinGonicComponent{Engine: engine, Addr: []string{addr}}, returned: make(chan struct{})}
+	bk := bike.NewBike()
+	bk.SetShutdownTimeout(50 * time.Millisecond)
+	bk.AddInstance(runner)
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		_ = bike.Run(ctx, bk)
+	}()
+	go func() {
+		for retry := 0; retry < 50; retry++ {
+			if response, err := http.Get("http://" + addr); err == nil {
+				response.Body.Close()
+				return
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}()
+	select {
+	case <-engine.entered:
+	case <-time.After(time.Second):
+		t.Error("Run must serve Engine")
+		cancel()
+		return
+	}
+	// When
+	cancel()
+	// Then
+	select {
+	case <-runner.returned:
+	case <-time.After(time.Second):
+		t.Error("Run must return when shutdown timeout expires")
+	}
+}
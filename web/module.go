@@ -0,0 +1,23 @@
+package web
+
+import "github.com/kybsa/bike"
+
+// NewModule return a module with GinGonicComponent using engine, exports *GinGonicComponent.
+// config.ConfigComponent must be exported by an imported module or added out of modules
+func NewModule(engine Engine, imports ...*bike.Module) *bike.Module {
+	return &bike.Module{
+		Name: "web",
+		Components: []bike.Component{
+			{
+				ID:          "Engine",
+				Constructor: func() Engine { return engine },
+			},
+			{
+				ID:          "GinGonicComponent",
+				Constructor: NewGinGonicComponent,
+			},
+		},
+		Imports: imports,
+		Exports: []any{(*GinGonicComponent)(nil)},
+	}
+}
@@ -0,0 +1,32 @@
+package web
+
+import (
+	"testing"
+
+	"github.com/kybsa/bike"
+	"github.com/kybsa/bike/config"
+)
+
+func TestNewModule_GivenConfigModule_WhenStart_ThenExportGinGonicComponent(t *testing.T) {
+	// Given
+	engineStruct := &EngineStruct{}
+	configModule := config.NewModule(&config.SimpleConfig{
+		MapConfig: map[string]string{"GinGonicComponent.Addr": "0.0.0.0:2020"},
+	})
+	bk := bike.NewBike()
+	bk.AddModule(NewModule(engineStruct, configModule))
+	// When
+	container, err := bk.Start()
+	// Then
+	if err != nil {
+		t.Errorf("Start must return nil error. Error:%s", err.Error())
+		return
+	}
+	ginGonicComponent, errGin := bike.Resolve[*GinGonicComponent](container)
+	if errGin != nil || ginGonicComponent.Engine != engineStruct || ginGonicComponent.Addr[0] != "0.0.0.0:2020" {
+		t.Error("Module must export GinGonicComponent")
+	}
+	if _, errEngine := bike.Resolve[Engine](container); errEngine == nil {
+		t.Error("Module must not export Engine")
+	}
+}
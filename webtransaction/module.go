@@ -0,0 +1,26 @@
+package webtransaction
+
+import "github.com/kybsa/bike"
+
+// NewModule return a module with TransactionComponent on Request scope and TransactionRequestController,
+// exports *TransactionComponent and *TransactionRequestController.
+// Request scope must be added to Bike. GormComponent, *RegistryController and Engine must be exported
+// by an imported module or added out of modules
+func NewModule(imports ...*bike.Module) *bike.Module {
+	return &bike.Module{
+		Name: "webtransaction",
+		Components: []bike.Component{
+			{
+				ID:          "TransactionComponent",
+				Constructor: NewTransactionComponent,
+				Scope:       Request,
+			},
+			{
+				ID:          "TransactionRequestController",
+				Constructor: NewTransactionRequestController,
+			},
+		},
+		Imports: imports,
+		Exports: []any{(*TransactionComponent)(nil), (*TransactionRequestController)(nil)},
+	}
+}
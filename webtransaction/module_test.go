@@ -0,0 +1,37 @@
+package webtransaction
+
+import (
+	"testing"
+
+	"github.com/kybsa/bike"
+)
+
+func TestNewModule_GivenDependencies_WhenStart_ThenExportTransactionComponents(t *testing.T) {
+	// Given
+	bk := bike.NewBike()
+	errCustomScope := bk.AddCustomScope(Request, "Request")
+	if errCustomScope != nil {
+		t.Errorf("AddCustomScope mus return nil error")
+	}
+	bk.Add(bike.Component{
+		Constructor: NewMockDBComponent,
+		Interfaces:  []any{(*GormComponent)(nil)},
+	})
+	bk.AddInstance(&RegistryController{})
+	bk.AddInstance(&MockEngine{}, bike.WithInterfaces((*Engine)(nil)))
+	bk.AddModule(NewModule())
+	// When
+	container, err := bk.Start()
+	// Then
+	if err != nil {
+		t.Errorf("Start must return nil error. Error:%s", err.Error())
+		return
+	}
+	if _, errController := bike.Resolve[*TransactionRequestController](container); errController != nil {
+		t.Error("Module must export TransactionRequestController")
+	}
+	if _, errTransaction := bike.ResolveInContext[*TransactionComponent](container, Request, "id"); errTransaction != nil {
+		t.Error("Module must export TransactionComponent")
+	}
+	_ = container.RemoveContext(Request, "id")
+}
@@ -404,3 +404,44 @@ func Test_GivenControllerReturnErrorAndTransactionFail_WhenHandRequest_ThenCallJ
 		t.Errorf("handRequest must call JSON with StatusInternalServerError")
 	}
 }
+
+func Test_GivenControllerConstructorPanics_WhenHandRequest_ThenCallJSONWithInternalServerError(t *testing.T) {
+	// Given
+	bk := bike.NewBike()
+	errCustomScope := bk.AddCustomScope(Request, "Request")
+	if errCustomScope != nil {
+		t.Errorf("AddCustomScope mus return nil error")
+	}
+	bk.Add(bike.Component{
+		Constructor: NewMockDBComponent,
+		Scope:       bike.Singleton,
+		Interfaces:  []any{(*GormComponent)(nil)},
+	})
+	bk.Add(bike.Component{
+		Constructor: NewTransactionComponent,
+		Scope:       Request,
+	})
+	bk.Add(bike.Component{
+		Constructor: func() *Controller { panic("controller panic") },
+		Scope:       Request,
+	})
+	container, errStartBike := bk.Start()
+	if errStartBike != nil {
+		t.Errorf("Start must no return nil. Error:[%s]", errStartBike.Error())
+	}
+	internalResponseWriter := &InternalResponseWriter{}
+	context := &gin.Context{
+		Writer: internalResponseWriter,
+	}
+	registryControllerItem := RegistryControllerItem{
+		Type: (*Controller)(nil),
+	}
+
+	// When
+	handRequest(context, registryControllerItem, container)
+
+	// Then
+	if internalResponseWriter.ValueStatus != http.StatusInternalServerError {
+		t.Errorf("handRequest must call JSON with StatusInternalServerError")
+	}
+}